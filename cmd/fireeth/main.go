@@ -2,17 +2,22 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/streamingfast/bstream"
 	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	bstreamtransform "github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
 	firecore "github.com/streamingfast/firehose-core"
 	fhCmd "github.com/streamingfast/firehose-core/cmd"
 	"github.com/streamingfast/firehose-core/firehose/info"
+	"github.com/streamingfast/firehose-core/node-manager/mindreader"
 	"github.com/streamingfast/firehose-ethereum/codec"
 	ethss "github.com/streamingfast/firehose-ethereum/substreams"
 	"github.com/streamingfast/firehose-ethereum/transform"
@@ -53,18 +58,21 @@ func Chain() *firecore.Chain[*pbeth.Block] {
 		BlockFactory: func() firecore.Block { return new(pbeth.Block) },
 
 		BlockIndexerFactories: map[string]firecore.BlockIndexerFactory[*pbeth.Block]{
-			transform.CombinedIndexerShortName: transform.NewEthCombinedIndexer,
+			transform.CombinedIndexerShortName: newCombinedIndexer,
 		},
 
 		BlockTransformerFactories: map[protoreflect.FullName]firecore.BlockTransformerFactory{
-			transform.HeaderOnlyMessageName:     transform.NewHeaderOnlyTransformFactory,
-			transform.CombinedFilterMessageName: transform.NewCombinedFilterTransformFactory,
-
-			transform.MultiCallToFilterMessageName: transform.NewMultiCallToFilterTransformFactory,
-			transform.MultiLogFilterMessageName:    transform.NewMultiLogFilterTransformFactory,
+			transform.HeaderOnlyMessageName:       transform.NewHeaderOnlyTransformFactory,
+			transform.CombinedFilterMessageName:   withAddressLists(transform.NewCombinedFilterTransformFactory),
+			transform.FieldProjectionMessageName:  transform.NewFieldProjectionTransformFactory,
+			transform.TransactionsOnlyMessageName: transform.NewTransactionsOnlyTransformFactory,
+			transform.LogsOnlyMessageName:         withAddressLists(transform.NewLogsOnlyTransformFactory),
+
+			transform.MultiCallToFilterMessageName: withAddressLists(transform.NewMultiCallToFilterTransformFactory),
+			transform.MultiLogFilterMessageName:    withAddressLists(transform.NewMultiLogFilterTransformFactory),
 		},
 
-		ConsoleReaderFactory: codec.NewConsoleReader,
+		ConsoleReaderFactory: newConsoleReader,
 
 		RegisterExtraStartFlags: func(flags *pflag.FlagSet) {
 			// The "\n" is there on purpose to improve readability of the added elements
@@ -76,6 +84,13 @@ func Chain() *firecore.Chain[*pbeth.Block] {
 
 			flags.StringArray("substreams-rpc-endpoints", nil, "Remote endpoints to contact to satisfy Substreams 'eth_call's")
 			flags.Uint64("substreams-rpc-gas-limit", 50_000_000, "Gas limit to set when calling RPC (set it to 0 for arbitrum chains, otherwise you should keep 50M)")
+			flags.Int("reader-node-block-decode-workers", 1, "Number of 'FIRE BLOCK' lines (Firehose protocol 3) decoded concurrently by the reader, blocks are still emitted in order, 1 decodes them serially")
+			flags.String("reader-node-payload-validation", "off", "Decode the payload of each 'FIRE BLOCK' line (Firehose protocol 3) and check its number, hash, parent hash, timestamp, version and detail level against the line, one of 'off', 'fail' (stop on mismatch) or 'quarantine' (write the line to 'reader-node-payload-quarantine-store-url' and keep going)")
+			flags.String("reader-node-payload-quarantine-store-url", "", "Store where the 'FIRE BLOCK' lines whose payload mismatch are written when 'reader-node-payload-validation' is 'quarantine'")
+			flags.Bool("reader-node-strict-header-commitments", false, "Recompute the logs bloom, transactions root and receipts root of each block read and stop if they disagree with the block's header")
+			flags.Uint64("reader-node-chain-id", 0, "Chain ID used to encode typed transactions when 'reader-node-strict-header-commitments' is set, inferred from each block's legacy transactions when 0")
+			flags.String("firehose-address-lists-store-url", "", "Store from which the address lists referenced by the 'addresses_url' of log and call filters are read, clients can only reference files under it, address lists are refused when empty")
+			flags.Bool("index-builder-transaction-positions", false, "Also write, next to each 'combined' index file, a 'combinedtrx' index file recording the transactions in which each key was seen, letting the filters pick the matching transactions of a block without scanning all of them")
 		},
 
 		RegisterSubstreamsExtensions: func() (wasm.WASMExtensioner, error) {
@@ -114,8 +129,12 @@ func Chain() *firecore.Chain[*pbeth.Block] {
 				parent.AddCommand(newPollerCmd(zlog, tracer))
 				parent.AddCommand(newOptimismPollerCmd(zlog, tracer))
 				parent.AddCommand(newScanForUnknownStatusCmd(zlog))
+				parent.AddCommand(newVerifyIndexCmd(zlog))
+				parent.AddCommand(newVerifyRootsCmd(zlog))
+				parent.AddCommand(newVerifyBlockHashesCmd(zlog))
 
 				registerGethEnforcePeersCmd(parent, chain.BinaryName(), zlog, tracer)
+				registerIndexCmd(parent, chain.BinaryName(), zlog)
 
 				return nil
 			},
@@ -123,9 +142,12 @@ func Chain() *firecore.Chain[*pbeth.Block] {
 			TransformFlags: &firecore.TransformFlags{
 				Register: func(flags *pflag.FlagSet) {
 					flags.Bool("header-only", false, "Apply the HeaderOnly transform sending back Block's header only (with few top-level fields), exclusive option")
-					flags.String("call-filters", "", "call filters (format: '[address1[+address2[+...]]]:[eventsig1[+eventsig2[+...]]]")
-					flags.String("log-filters", "", "log filters (format: '[address1[+address2[+...]]]:[eventsig1[+eventsig2[+...]]]")
-					flags.Bool("send-all-block-headers", false, "ask for all the blocks to be sent (header-only if there is no match)")
+					registerFilterFlags(flags)
+					flags.String("drop-fields", "", "comma separated paths of block fields to remove from the blocks sent, relative to 'sf.ethereum.type.v2.Block' (e.g. 'transaction_traces.calls.gas_changes,balance_changes')")
+					flags.Bool("drop-heavy-fields", false, "remove keccak preimages, gas changes, storage changes and non-root call inputs of every call from the blocks sent")
+					flags.Bool("transactions-only", false, "receive a flat list of the transactions (each with a reference to its block) instead of blocks, applied after the filters and field projection")
+					flags.Bool("logs-only", false, "receive a flat list of the logs (each with a reference to its transaction and block) instead of blocks, reduced to the logs matching the ungrouped log filters ('log-filters' and 'filters-file') when set")
+					flags.Bool("include-reverted-logs", false, "with 'logs-only', also receive the logs emitted by calls whose state was reverted")
 				},
 
 				Parse: parseTransformFlags,
@@ -137,6 +159,71 @@ func Chain() *firecore.Chain[*pbeth.Block] {
 	return chain
 }
 
+func newConsoleReader(lines chan string, blockEncoder firecore.BlockEncoder, logger *zap.Logger, tracer logging.Tracer) (mindreader.ConsolerReader, error) {
+	payloadValidation, err := codec.ParsePayloadValidationMode(viper.GetString("reader-node-payload-validation"))
+	if err != nil {
+		return nil, err
+	}
+
+	var quarantineStore dstore.Store
+	if quarantineStoreURL := viper.GetString("reader-node-payload-quarantine-store-url"); quarantineStoreURL != "" {
+		quarantineStore, err = dstore.NewStore(quarantineStoreURL, "", "", true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create quarantine store: %w", err)
+		}
+	}
+
+	opts := []codec.ConsoleReaderOption{
+		codec.WithBlockDecodeWorkers(viper.GetInt("reader-node-block-decode-workers")),
+		codec.WithPayloadValidation(payloadValidation, quarantineStore),
+	}
+
+	if viper.GetBool("reader-node-strict-header-commitments") {
+		var chainID *big.Int
+		if value := viper.GetUint64("reader-node-chain-id"); value != 0 {
+			chainID = new(big.Int).SetUint64(value)
+		}
+		opts = append(opts, codec.WithStrictHeaderCommitments(chainID))
+	}
+
+	return codec.NewConsoleReader(lines, blockEncoder, logger, tracer, opts...)
+}
+
+var configureAddressListsOnce sync.Once
+var configureAddressListsErr error
+
+// withAddressLists configures, once, the store of the address lists before creating the transform
+// factory, the factories of the filters able to reference an address list are all wrapped with it
+func withAddressLists(factory firecore.BlockTransformerFactory) firecore.BlockTransformerFactory {
+	return func(indexStore dstore.Store, indexPossibleSizes []uint64) (*bstreamtransform.Factory, error) {
+		configureAddressListsOnce.Do(func() {
+			storeURL := viper.GetString("firehose-address-lists-store-url")
+			if storeURL == "" {
+				return
+			}
+
+			store, err := dstore.NewStore(storeURL, "", "", false)
+			if err != nil {
+				configureAddressListsErr = fmt.Errorf("unable to create address lists store: %w", err)
+				return
+			}
+			transform.ConfigureAddressLists(store)
+		})
+
+		if configureAddressListsErr != nil {
+			return nil, configureAddressListsErr
+		}
+		return factory(indexStore, indexPossibleSizes)
+	}
+}
+
+func newCombinedIndexer(indexStore dstore.Store, indexSize uint64) (firecore.BlockIndexer[*pbeth.Block], error) {
+	if viper.GetBool("index-builder-transaction-positions") {
+		return transform.NewEthCombinedIndexerWithTransactionPositions(indexStore, indexSize)
+	}
+	return transform.NewEthCombinedIndexer(indexStore, indexSize)
+}
+
 // Version value, injected via go build `ldflags` at build time, **must** not be removed or inlined
 var version = "dev"
 
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-ethereum/transform"
+	"go.uber.org/zap"
+)
+
+func registerIndexCmd(parent *cobra.Command, binary string, logger *zap.Logger) {
+	registerGroup(parent,
+		Group("index", "Tools to inspect the 'combined' index files used to skip blocks not matching a filter",
+			Command(createIndexQueryE(logger),
+				"query <index-store> <start-block> <stop-block>",
+				"Print the blocks in range [start-block, stop-block) selected by the index for the filter given through the filter flags",
+				ExactArgs(3),
+				Flags(func(flags *pflag.FlagSet) {
+					registerFilterFlags(flags)
+					registerIndexBlockSizesFlag(flags)
+					flags.Bool("count-only", false, "Only print the count of matching blocks of each index file instead of the blocks themselves")
+					flags.String("address-lists-store-url", "", "Store from which the address lists referenced by the 'addresses_url' of the filters are read, like 'firehose-address-lists-store-url' does for the Firehose, address lists are refused when empty")
+				}),
+				ExamplePrefixed(fmt.Sprintf("%s tools index query", binary), `
+					# Blocks in which the USDT contract was called
+					gs://bucket/eth-mainnet/index 19000000 19100000 --call-filters=0xdac17f958d2ee523a2206206994597c13d831ec7:
+
+					# Count of blocks per index file with a Transfer event of the USDT contract
+					gs://bucket/eth-mainnet/index 19000000 19100000 --log-filters='0xdac17f958d2ee523a2206206994597c13d831ec7:Transfer(address,address,uint256)' --count-only
+				`),
+			),
+			Command(createIndexStatsE(logger),
+				"stats <index-store> <start-block> <stop-block>",
+				"Print the number of keys of each kind and the keys seen in the most blocks for the index files covering [start-block, stop-block)",
+				ExactArgs(3),
+				Flags(func(flags *pflag.FlagSet) {
+					flags.Int("top", 10, "Number of keys, ordered by the number of blocks they were seen in, to print for each kind of key")
+					flags.Bool("aggregate", false, "Print a single report aggregating all the index files instead of one report per index file")
+					registerIndexBlockSizesFlag(flags)
+				}),
+				ExamplePrefixed(fmt.Sprintf("%s tools index stats", binary), `
+					# Top 5 keys of each kind for each index file
+					gs://bucket/eth-mainnet/index 19000000 19100000 --top=5
+
+					# Top 20 keys of each kind over the whole range
+					gs://bucket/eth-mainnet/index 19000000 19100000 --top=20 --aggregate
+				`),
+			),
+		),
+	)
+}
+
+// registerIndexBlockSizesFlag registers the flag read by indexBlockSizes, the index files are looked
+// for from the boundary of the largest size at or below the start block
+func registerIndexBlockSizesFlag(flags *pflag.FlagSet) {
+	flags.UintSlice("index-block-sizes", []uint{100000, 10000, 1000, 100}, "Sizes of the index files of the store, like 'common-index-block-sizes', an index file of a size larger than all of them may not be found")
+}
+
+func indexBlockSizes(cmd *cobra.Command) (out []uint64) {
+	for _, size := range sflags.MustGetUintSlice(cmd, "index-block-sizes") {
+		out = append(out, uint64(size))
+	}
+	return out
+}
+
+func parseIndexRangeArgs(args []string) (store dstore.Store, start, stop uint64, err error) {
+	store, err = dstore.NewStore(args[0], "", "", false)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("unable to create index store: %w", err)
+	}
+
+	if start, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid start block %q: %w", args[1], err)
+	}
+
+	if stop, err = strconv.ParseUint(args[2], 10, 64); err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid stop block %q: %w", args[2], err)
+	}
+
+	if stop <= start {
+		return nil, 0, 0, fmt.Errorf("stop block must be greater than start block")
+	}
+	return
+}
+
+func createIndexQueryE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		store, start, stop, err := parseIndexRangeArgs(args)
+		if err != nil {
+			return err
+		}
+
+		filters, err := parseFilters(cmd)
+		if err != nil {
+			return err
+		}
+		if filters == nil {
+			return fmt.Errorf("at least one filter is required, see the filter flags")
+		}
+
+		if storeURL := sflags.MustGetString(cmd, "address-lists-store-url"); storeURL != "" {
+			addressListsStore, err := dstore.NewStore(storeURL, "", "", false)
+			if err != nil {
+				return fmt.Errorf("unable to create address lists store: %w", err)
+			}
+			transform.ConfigureAddressLists(addressListsStore)
+		}
+
+		query, err := transform.NewCombinedIndexQuery(filters)
+		if err != nil {
+			return err
+		}
+
+		files, err := transform.ListCombinedIndexFiles(ctx, store, start, stop, indexBlockSizes(cmd))
+		if err != nil {
+			return err
+		}
+
+		countOnly := sflags.MustGetBool(cmd, "count-only")
+
+		var matchCount, indexedCount uint64
+		for _, file := range files {
+			bundle, err := transform.ReadCombinedIndexBundle(ctx, store, file)
+			if err != nil {
+				return err
+			}
+
+			low, high := max(start, file.LowBlockNum), min(stop, file.ExclusiveHighBlockNum())
+			indexedCount += high - low
+
+			var bundleMatchCount uint64
+			for _, blockNum := range query(bundle) {
+				if blockNum < low || blockNum >= high {
+					continue
+				}
+
+				bundleMatchCount++
+				if !countOnly {
+					fmt.Println(blockNum)
+				}
+			}
+
+			logger.Debug("queried index file", zap.String("filename", file.Filename), zap.Uint64("match_count", bundleMatchCount))
+			if countOnly {
+				fmt.Printf("%s: %d matching blocks out of %d\n", file.Filename, bundleMatchCount, high-low)
+			}
+			matchCount += bundleMatchCount
+		}
+
+		fmt.Printf("Matched %d blocks out of %d indexed blocks (%s) using %d index files\n", matchCount, indexedCount, percent(matchCount, indexedCount), len(files))
+		if missing := (stop - start) - indexedCount; missing != 0 {
+			fmt.Printf("Warning: %d blocks of the range [%d, %d) are not covered by any index file, they are never skipped\n", missing, start, stop)
+		}
+		return nil
+	}
+}
+
+// indexKeyStats accumulates, for the keys of one kind, the number of blocks each key was seen in
+type indexKeyStats struct {
+	kind        string
+	blockCounts map[string]uint64
+}
+
+func (s *indexKeyStats) total() (out uint64) {
+	for _, count := range s.blockCounts {
+		out += count
+	}
+	return
+}
+
+type indexStats map[string]*indexKeyStats
+
+func (s indexStats) add(bundle *transform.CombinedIndexBundle) {
+	for key, bitmap := range bundle.Bitmaps {
+		kind, value := transform.CombinedIndexKeyKind(key)
+		if kind == "" {
+			kind = "unknown"
+		}
+
+		stats := s[kind]
+		if stats == nil {
+			stats = &indexKeyStats{kind: kind, blockCounts: make(map[string]uint64)}
+			s[kind] = stats
+		}
+		stats.blockCounts[value] += bitmap.GetCardinality()
+	}
+}
+
+func (s indexStats) print(top int) {
+	kinds := make([]string, 0, len(s))
+	for kind := range s {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		stats := s[kind]
+		fmt.Printf("  %s: %d keys, %d key/block pairs\n", kind, len(stats.blockCounts), stats.total())
+
+		values := make([]string, 0, len(stats.blockCounts))
+		for value := range stats.blockCounts {
+			values = append(values, value)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if stats.blockCounts[values[i]] == stats.blockCounts[values[j]] {
+				return values[i] < values[j]
+			}
+			return stats.blockCounts[values[i]] > stats.blockCounts[values[j]]
+		})
+
+		// Presence keys (e.g. 'N') have no value, they are already counted by the kind's line
+		printed := 0
+		for _, value := range values {
+			if printed >= top {
+				break
+			}
+			if value == "" {
+				continue
+			}
+			fmt.Printf("    %s: %d blocks\n", value, stats.blockCounts[value])
+			printed++
+		}
+	}
+}
+
+func createIndexStatsE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		store, start, stop, err := parseIndexRangeArgs(args)
+		if err != nil {
+			return err
+		}
+
+		top := sflags.MustGetInt(cmd, "top")
+		aggregate := sflags.MustGetBool(cmd, "aggregate")
+
+		files, err := transform.ListCombinedIndexFiles(ctx, store, start, stop, indexBlockSizes(cmd))
+		if err != nil {
+			return err
+		}
+
+		aggregated := indexStats{}
+		var keyCount int
+		for _, file := range files {
+			bundle, err := transform.ReadCombinedIndexBundle(ctx, store, file)
+			if err != nil {
+				return err
+			}
+			logger.Debug("read index file", zap.String("filename", file.Filename), zap.Int("key_count", len(bundle.Bitmaps)))
+
+			if aggregate {
+				aggregated.add(bundle)
+				keyCount += len(bundle.Bitmaps)
+				continue
+			}
+
+			fmt.Printf("Index file %s, blocks [%d, %d), %d keys\n", file.Filename, file.LowBlockNum, file.ExclusiveHighBlockNum(), len(bundle.Bitmaps))
+			stats := indexStats{}
+			stats.add(bundle)
+			stats.print(top)
+		}
+
+		if aggregate {
+			fmt.Printf("%d index files covering [%d, %d), %d keys in total\n", len(files), start, stop, keyCount)
+			aggregated.print(top)
+		}
+		return nil
+	}
+}
+
+func percent(count, total uint64) string {
+	if total == 0 {
+		return "0.00%"
+	}
+	return fmt.Sprintf("%.2f%%", float64(count)*100/float64(total))
+}
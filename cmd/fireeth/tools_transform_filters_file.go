@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sigs.k8s.io/yaml"
+)
+
+var bigIntFullName = proto.MessageName(&pbeth.BigInt{})
+
+// maxExactFloatInteger bounds the integers read exactly as float64, from it on a number may have been rounded
+const maxExactFloatInteger = 1 << 53
+
+// readFiltersFile reads a JSON or YAML document describing a whole CombinedFilter. It follows the
+// protobuf JSON mapping except that bytes are written in hex, that method and event signatures can
+// also be textual (e.g. 'transfer(address,uint256)') and that BigInt values are decimal strings.
+func readFiltersFile(path string) (*pbtransform.CombinedFilter, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read filters file: %w", err)
+	}
+
+	// YAML being a superset of JSON, both are handled the same way
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse filters file %q: %w", path, err)
+	}
+
+	var document any
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return nil, fmt.Errorf("parse filters file %q: %w", path, err)
+	}
+
+	filter := &pbtransform.CombinedFilter{}
+	document, err = toProtoJSON(document, filter.ProtoReflect().Descriptor(), "")
+	if err != nil {
+		return nil, fmt.Errorf("filters file %q: %w", path, err)
+	}
+
+	protoJSONContent, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("filters file %q: %w", path, err)
+	}
+
+	if err := protojson.Unmarshal(protoJSONContent, filter); err != nil {
+		return nil, fmt.Errorf("filters file %q: %w", path, err)
+	}
+	return filter, nil
+}
+
+// toProtoJSON converts the human friendly values of the document into their protobuf JSON
+// mapping, walking it along the message descriptor
+func toProtoJSON(value any, desc protoreflect.MessageDescriptor, path string) (any, error) {
+	if desc.FullName() == bigIntFullName {
+		return bigIntToProtoJSON(value, path)
+	}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected an object, got %T", pathOrRoot(path), value)
+	}
+
+	for key, fieldValue := range fields {
+		field := desc.Fields().ByJSONName(key)
+		if field == nil {
+			field = desc.Fields().ByName(protoreflect.Name(key))
+		}
+		if field == nil {
+			// Let protojson report unknown fields
+			continue
+		}
+
+		fieldPath := path + "." + key
+		if !field.IsList() {
+			converted, err := fieldToProtoJSON(fieldValue, field, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = converted
+			continue
+		}
+
+		list, ok := fieldValue.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a list, got %T", fieldPath, fieldValue)
+		}
+		for i, element := range list {
+			converted, err := fieldToProtoJSON(element, field, fmt.Sprintf("%s[%d]", fieldPath, i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+	}
+
+	return fields, nil
+}
+
+func fieldToProtoJSON(value any, field protoreflect.FieldDescriptor, path string) (any, error) {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		return toProtoJSON(value, field.Message(), path)
+
+	case protoreflect.BytesKind:
+		if number, ok := value.(float64); ok {
+			// YAML reads an unquoted hex value like 0x095ea7b3 as an integer, losing its leading zeros
+			return nil, fmt.Errorf("%s: expected a string, got the number %v, hex values must be quoted (e.g. \"0x095ea7b3\") to not be read as numbers", path, number)
+		}
+
+		in, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a string, got %T", path, value)
+		}
+
+		var out []byte
+		var err error
+		switch field.Name() {
+		case "signatures":
+			out, err = parseMethodSignature(in)
+		case "event_signatures":
+			out, err = parseEventSignature(in)
+		default:
+			out, err = eth.NewHex(in)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid value %q: %w", path, in, err)
+		}
+		return base64.StdEncoding.EncodeToString(out), nil
+	}
+
+	return value, nil
+}
+
+func bigIntToProtoJSON(value any, path string) (any, error) {
+	var in string
+	switch v := value.(type) {
+	case string:
+		in = v
+	case float64:
+		// Amounts from 2^53 on may lose precision as JSON numbers, those must be provided as strings
+		if v != math.Trunc(v) || math.Abs(v) >= maxExactFloatInteger {
+			return nil, fmt.Errorf("%s: amount %v cannot be represented exactly as a number, it must be quoted as a decimal string", path, v)
+		}
+		in = big.NewFloat(v).Text('f', 0)
+	default:
+		return nil, fmt.Errorf("%s: expected a decimal amount, got %T", path, value)
+	}
+
+	amount, ok := new(big.Int).SetString(in, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("%s: invalid amount %q, must be a positive decimal amount", path, in)
+	}
+	return map[string]any{"bytes": base64.StdEncoding.EncodeToString(amount.Bytes())}, nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path[1:]
+}
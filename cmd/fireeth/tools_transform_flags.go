@@ -2,25 +2,46 @@ package main
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/streamingfast/cli/sflags"
 	"github.com/streamingfast/eth-go"
 	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 func parseTransformFlags(cmd *cobra.Command, logger *zap.Logger) (transforms []*anypb.Any, err error) {
-	filters, err := parseFilters(sflags.MustGetString(cmd, "call-filters"), sflags.MustGetString(cmd, "log-filters"), sflags.MustGetBool(cmd, "send-all-block-headers"))
+	filters, err := parseFilters(cmd)
 	if err != nil {
 		return nil, err
 	}
 
+	projection := parseFieldProjection(cmd)
+
 	headerOnly := sflags.MustGetBool(cmd, "header-only")
 	if filters != nil && headerOnly {
-		return nil, fmt.Errorf("'header-only' flag is exclusive with 'call-filters', 'log-filters' and 'send-all-block-headers' choose either 'header-only' or a combination of the others")
+		return nil, fmt.Errorf("'header-only' flag is exclusive with 'call-filters', 'log-filters', 'transaction-filters', their 'exclude-' counterparts and 'send-all-block-headers' choose either 'header-only' or a combination of the others")
+	}
+
+	if projection != nil && headerOnly {
+		return nil, fmt.Errorf("'header-only' flag is exclusive with 'drop-fields' and 'drop-heavy-fields', the header only transform already drops all of them")
+	}
+
+	transactionsOnly := sflags.MustGetBool(cmd, "transactions-only")
+	if transactionsOnly && headerOnly {
+		return nil, fmt.Errorf("'header-only' flag is exclusive with 'transactions-only', the header only transform does not keep any transaction")
+	}
+
+	logsOnly := sflags.MustGetBool(cmd, "logs-only")
+	if logsOnly && (headerOnly || transactionsOnly) {
+		return nil, fmt.Errorf("'logs-only' flag is exclusive with 'header-only' and 'transactions-only', choose a single output")
 	}
 
 	if headerOnly {
@@ -38,78 +59,541 @@ func parseTransformFlags(cmd *cobra.Command, logger *zap.Logger) (transforms []*
 			return nil, err
 		}
 
-		return []*anypb.Any{t}, nil
+		transforms = append(transforms, t)
+	}
+
+	// The projection must come after the filters as it works on their output
+	if projection != nil {
+		t, err := anypb.New(projection)
+		if err != nil {
+			return nil, err
+		}
+
+		transforms = append(transforms, t)
+	}
+
+	// Transactions only and logs only outputs are not blocks anymore, they must be last
+	if transactionsOnly {
+		t, err := anypb.New(&pbtransform.TransactionsOnly{})
+		if err != nil {
+			return nil, err
+		}
+
+		transforms = append(transforms, t)
+	}
+
+	if logsOnly {
+		// The logs are reduced to the ones matching the log filters and not only their transactions,
+		// a grouped log filter only matches along with the other filters of its group
+		for _, group := range filters.GetFilterGroups() {
+			if len(group.LogFilters) != 0 {
+				return nil, fmt.Errorf("'logs-only' flag does not support grouped log filters, the logs matching them cannot be told apart from the other logs of the transactions")
+			}
+		}
+
+		t, err := anypb.New(&pbtransform.LogsOnly{
+			LogFilters:          filters.GetLogFilters(),
+			IncludeRevertedLogs: sflags.MustGetBool(cmd, "include-reverted-logs"),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		transforms = append(transforms, t)
 	}
 
 	return
 }
 
-func parseFilters(callFilters, logFilters string, sendAllBlockHeaders bool) (*pbtransform.CombinedFilter, error) {
+func parseFieldProjection(cmd *cobra.Command) *pbtransform.FieldProjection {
+	var paths []string
+	for _, path := range strings.Split(sflags.MustGetString(cmd, "drop-fields"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	dropHeavyFields := sflags.MustGetBool(cmd, "drop-heavy-fields")
+	if len(paths) == 0 && !dropHeavyFields {
+		return nil
+	}
+
+	return &pbtransform.FieldProjection{
+		DropPaths:           paths,
+		DropKeccakPreimages: dropHeavyFields,
+		DropGasChanges:      dropHeavyFields,
+		DropStorageChanges:  dropHeavyFields,
+		DropDeepCallInputs:  dropHeavyFields,
+	}
+}
+
+// registerFilterFlags registers the flags read by parseFilters, shared by the commands sending
+// a CombinedFilter and the ones evaluating it against the index
+func registerFilterFlags(flags *pflag.FlagSet) {
+	flags.String("call-filters", "", "call filters, method signatures being either 4 bytes selectors or textual like 'transfer(address,uint256)' (format: '[group=][address1[+address2[+...]]]:[methodsig1[+methodsig2[+...]]][:[calltype1[+calltype2[+...]]][:[max_depth][:[succeeded_only]]]]', call types being call, callcode, delegate, static or create and max depth 0 being the root call), filters prefixed by the same group name in 'call-filters', 'log-filters' and 'transaction-filters' must all match within one transaction")
+	flags.String("log-filters", "", "log filters, event signatures being either 32 bytes hashes or textual like 'Transfer(address,indexed address,uint256)' (format: '[group=][address1[+address2[+...]]]:[eventsig1[+eventsig2[+...]]][:[topic1[+...]][:[topic2[+...]][:[topic3[+...]]]]]', topics being the 32 bytes indexed arguments, see 'call-filters' for the optional 'group=' prefix")
+	flags.String("transaction-filters", "", "transaction filters on top-level transactions only (format: '[group=][from1[+from2[+...]]]:[to1[+to2[+...]]]:[methodsig1[+methodsig2[+...]]]'), see 'call-filters' for the optional 'group=' prefix")
+	flags.String("balance-change-filters", "", "balance change filters, also reducing block level balance changes to matching ones (format: '[address1[+address2[+...]]]:[reason1[+reason2[+...]]]', reasons like 'withdrawal' or 'reward_mine_block')")
+	flags.String("storage-change-filters", "", "storage change filters on contract storage slots effectively written (format: 'address1[+address2[+...]]:[slotkey1[+slotkey2[+...]]]')")
+	flags.String("value-transfer-filters", "", "native value transfer filters, including internal transfers, a filter with all parts empty matching all transfers (format: '[address1[+address2[+...]]]:[from1[+from2[+...]]]:[to1[+to2[+...]]]:[min_value_wei]')")
+	flags.String("contract-creation-filters", "", "contract creation filters, a filter with both parts empty matching all deployments (format: '[deployer1[+deployer2[+...]]]:[codehashprefix1[+codehashprefix2[+...]]]')")
+	flags.String("exclude-call-filters", "", "call filters removing matching transactions from the ones included by the other filters, same format as 'call-filters'")
+	flags.String("exclude-log-filters", "", "log filters removing matching transactions from the ones included by the other filters, same format as 'log-filters'")
+	flags.String("exclude-transaction-filters", "", "transaction filters removing matching transactions from the ones included by the other filters, same format as 'transaction-filters'")
+	flags.String("filters-file", "", "path to a JSON or YAML file describing a whole 'sf.ethereum.transform.v1.CombinedFilter' (bytes in hex, signatures in hex or textual like 'transfer(address,uint256)'), extended by the other filter flags, the 'addresses_url' address lists it references are read by the server, from its own address lists store")
+	flags.Bool("send-all-block-headers", false, "ask for all the blocks to be sent (header-only if there is no match)")
+	flags.Bool("prune-calls", false, "only keep the calls matching 'call-filters', emitting a log matching 'log-filters' or matching the balance change, storage change, contract creation and value transfer filters (and their ancestors) in the transactions sent, a transaction kept only by 'transaction-filters' being reduced to its root call")
+}
+
+// parseFilters returns the CombinedFilter described by 'filters-file', if any, extended with the
+// one described by the filter flags
+func parseFilters(cmd *cobra.Command) (*pbtransform.CombinedFilter, error) {
+	filters, err := parseFilterFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if filtersFile := sflags.MustGetString(cmd, "filters-file"); filtersFile != "" {
+		out, err := readFiltersFile(filtersFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if filters != nil {
+			proto.Merge(out, filters)
+		}
+		filters = out
+	}
+
+	// Call pruning applies to the filter whichever flags or file it comes from
+	if filters != nil && sflags.MustGetBool(cmd, "prune-calls") {
+		filters.PruneCalls = true
+	}
+	return filters, nil
+}
+
+func parseFilterFlags(cmd *cobra.Command) (*pbtransform.CombinedFilter, error) {
 	mf := &pbtransform.CombinedFilter{}
 
-	if callFilters == "" && logFilters == "" && !sendAllBlockHeaders {
+	callFilters := sflags.MustGetString(cmd, "call-filters")
+	logFilters := sflags.MustGetString(cmd, "log-filters")
+	transactionFilters := sflags.MustGetString(cmd, "transaction-filters")
+	balanceChangeFilters := sflags.MustGetString(cmd, "balance-change-filters")
+	storageChangeFilters := sflags.MustGetString(cmd, "storage-change-filters")
+	contractCreationFilters := sflags.MustGetString(cmd, "contract-creation-filters")
+	valueTransferFilters := sflags.MustGetString(cmd, "value-transfer-filters")
+	excludeCallFilters := sflags.MustGetString(cmd, "exclude-call-filters")
+	excludeLogFilters := sflags.MustGetString(cmd, "exclude-log-filters")
+	excludeTransactionFilters := sflags.MustGetString(cmd, "exclude-transaction-filters")
+	sendAllBlockHeaders := sflags.MustGetBool(cmd, "send-all-block-headers")
+
+	if callFilters == "" && logFilters == "" && transactionFilters == "" && balanceChangeFilters == "" && storageChangeFilters == "" && contractCreationFilters == "" && valueTransferFilters == "" &&
+		excludeCallFilters == "" && excludeLogFilters == "" && excludeTransactionFilters == "" &&
+		!sendAllBlockHeaders {
 		return nil, nil
 	}
-	if callFilters != "" {
-		for _, filter := range strings.Split(callFilters, ",") {
-			if filter == "" {
-				continue
-			}
-			parts := strings.Split(filter, ":")
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("option --call-filters must be of type address_hash+address_hash+address_hash:event_sig_hash+event_sig_hash (repeated, separated by comma)")
+
+	ungroupedFilters := map[string]string{
+		"balance-change-filters":      balanceChangeFilters,
+		"storage-change-filters":      storageChangeFilters,
+		"contract-creation-filters":   contractCreationFilters,
+		"value-transfer-filters":      valueTransferFilters,
+		"exclude-call-filters":        excludeCallFilters,
+		"exclude-log-filters":         excludeLogFilters,
+		"exclude-transaction-filters": excludeTransactionFilters,
+	}
+	for flagName, value := range ungroupedFilters {
+		if strings.Contains(value, "=") {
+			return nil, fmt.Errorf("option --%s does not accept filter groups ('group=' prefix), only --call-filters, --log-filters and --transaction-filters do", flagName)
+		}
+	}
+
+	var err error
+	groups := newFilterGroups()
+	if callFilters, err = groups.split(callFilters, func(g *pbtransform.FilterGroup, value string) (err error) {
+		g.CallFilters, err = parseCallFilters("call-filters", value)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("option --call-filters: %w", err)
+	}
+	if logFilters, err = groups.split(logFilters, func(g *pbtransform.FilterGroup, value string) (err error) {
+		g.LogFilters, err = parseLogFilters("log-filters", value)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("option --log-filters: %w", err)
+	}
+	if transactionFilters, err = groups.split(transactionFilters, func(g *pbtransform.FilterGroup, value string) (err error) {
+		g.TransactionFilters, err = parseTransactionFilters("transaction-filters", value)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("option --transaction-filters: %w", err)
+	}
+
+	if mf.FilterGroups, err = groups.parse(); err != nil {
+		return nil, err
+	}
+	if mf.CallFilters, err = parseCallFilters("call-filters", callFilters); err != nil {
+		return nil, err
+	}
+	if mf.LogFilters, err = parseLogFilters("log-filters", logFilters); err != nil {
+		return nil, err
+	}
+	if mf.TransactionFilters, err = parseTransactionFilters("transaction-filters", transactionFilters); err != nil {
+		return nil, err
+	}
+	if mf.BalanceChangeFilters, err = parseBalanceChangeFilters("balance-change-filters", balanceChangeFilters); err != nil {
+		return nil, err
+	}
+	if mf.StorageChangeFilters, err = parseStorageChangeFilters("storage-change-filters", storageChangeFilters); err != nil {
+		return nil, err
+	}
+	if mf.ContractCreationFilters, err = parseContractCreationFilters("contract-creation-filters", contractCreationFilters); err != nil {
+		return nil, err
+	}
+	if mf.ValueTransferFilters, err = parseValueTransferFilters("value-transfer-filters", valueTransferFilters); err != nil {
+		return nil, err
+	}
+	if mf.ExcludeCallFilters, err = parseCallFilters("exclude-call-filters", excludeCallFilters); err != nil {
+		return nil, err
+	}
+	if mf.ExcludeLogFilters, err = parseLogFilters("exclude-log-filters", excludeLogFilters); err != nil {
+		return nil, err
+	}
+	if mf.ExcludeTransactionFilters, err = parseTransactionFilters("exclude-transaction-filters", excludeTransactionFilters); err != nil {
+		return nil, err
+	}
+
+	if sendAllBlockHeaders {
+		mf.SendAllBlockHeaders = true
+	}
+
+	return mf, nil
+}
+
+// filterGroups gathers the entries of the filters flags prefixed by a group name ('name=filter'),
+// entries sharing the same name across flags forming a single pbtransform.FilterGroup
+type filterGroups struct {
+	names   []string
+	parsers map[string][]func(g *pbtransform.FilterGroup) error
+}
+
+func newFilterGroups() *filterGroups {
+	return &filterGroups{parsers: make(map[string][]func(g *pbtransform.FilterGroup) error)}
+}
+
+// split returns the entries of the flag value without a group name, the grouped ones being
+// registered to be parsed by parse into their group
+func (g *filterGroups) split(value string, parse func(g *pbtransform.FilterGroup, value string) error) (string, error) {
+	filters, err := splitTopLevel(value, ',')
+	if err != nil {
+		return "", err
+	}
+
+	var ungrouped []string
+	grouped := make(map[string][]string)
+	var names []string
+	for _, filter := range filters {
+		name, groupFilter, found := strings.Cut(filter, "=")
+		if !found {
+			ungrouped = append(ungrouped, filter)
+			continue
+		}
+
+		if _, seen := grouped[name]; !seen {
+			names = append(names, name)
+		}
+		grouped[name] = append(grouped[name], groupFilter)
+	}
+
+	for _, name := range names {
+		if _, seen := g.parsers[name]; !seen {
+			g.names = append(g.names, name)
+		}
+
+		groupValue := strings.Join(grouped[name], ",")
+		g.parsers[name] = append(g.parsers[name], func(group *pbtransform.FilterGroup) error {
+			return parse(group, groupValue)
+		})
+	}
+
+	return strings.Join(ungrouped, ","), nil
+}
+
+func (g *filterGroups) parse() (out []*pbtransform.FilterGroup, err error) {
+	for _, name := range g.names {
+		group := &pbtransform.FilterGroup{}
+		for _, parse := range g.parsers[name] {
+			if err := parse(group); err != nil {
+				return nil, fmt.Errorf("filter group %q: %w", name, err)
 			}
-			var addrs []eth.Address
-			for _, a := range strings.Split(parts[0], "+") {
-				if a != "" {
-					addr := eth.MustNewAddressLoose(a)
-					addrs = append(addrs, addr)
+		}
+
+		out = append(out, group)
+	}
+
+	return out, nil
+}
+
+func parseCallFilters(flagName string, callFilters string) (out []*pbtransform.CallToFilter, err error) {
+	filters, err := splitTopLevel(callFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) < 2 || len(parts) > 5 {
+			return nil, fmt.Errorf("option --%s must be of type address_hash+address_hash+address_hash:event_sig_hash+event_sig_hash[:call_type+call_type[:max_depth[:succeeded_only]]] (repeated, separated by comma)", flagName)
+		}
+		var addrs []eth.Address
+		for _, a := range strings.Split(parts[0], "+") {
+			if a != "" {
+				addr, err := eth.NewAddressLoose(a)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid address %q: %w", flagName, a, err)
 				}
+				addrs = append(addrs, addr)
 			}
-			var sigs []eth.Hash
-			for _, s := range strings.Split(parts[1], "+") {
-				if s != "" {
-					sig := eth.MustNewHash(s)
-					sigs = append(sigs, sig)
+		}
+		var sigs []eth.Hash
+		for _, s := range strings.Split(parts[1], "+") {
+			if s != "" {
+				sig, err := parseMethodSignature(s)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid method signature %q: %w", flagName, s, err)
 				}
+				sigs = append(sigs, sig)
 			}
+		}
 
-			mf.CallFilters = append(mf.CallFilters, basicCallToFilter(addrs, sigs))
+		callFilter := basicCallToFilter(addrs, sigs)
+		if len(parts) > 2 {
+			for _, t := range strings.Split(parts[2], "+") {
+				if t == "" {
+					continue
+				}
+				callType, found := pbeth.CallType_value[strings.ToUpper(t)]
+				if !found {
+					return nil, fmt.Errorf("option --%s: invalid call type %q, valid values are call, callcode, delegate, static and create", flagName, t)
+				}
+				callFilter.CallTypes = append(callFilter.CallTypes, pbeth.CallType(callType))
+			}
+		}
+		if len(parts) > 3 && parts[3] != "" {
+			maxDepth, err := strconv.ParseUint(parts[3], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("option --%s: invalid max depth %q: %w", flagName, parts[3], err)
+			}
+			callFilter.MaxDepth = proto.Uint32(uint32(maxDepth))
+		}
+		if len(parts) > 4 && parts[4] != "" {
+			if callFilter.SucceededOnly, err = strconv.ParseBool(parts[4]); err != nil {
+				return nil, fmt.Errorf("option --%s: invalid succeeded only value %q: %w", flagName, parts[4], err)
+			}
 		}
+
+		out = append(out, callFilter)
 	}
 
-	if logFilters != "" {
-		for _, filter := range strings.Split(logFilters, ",") {
-			if filter == "" {
-				continue
+	return out, nil
+}
+
+func parseLogFilters(flagName string, logFilters string) (out []*pbtransform.LogFilter, err error) {
+	filters, err := splitTopLevel(logFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) < 2 || len(parts) > 5 {
+			return nil, fmt.Errorf("option --%s must be of type address_hash+address_hash+address_hash:event_sig_hash+event_sig_hash[:topic1+topic1[:topic2+topic2[:topic3+topic3]]] (repeated, separated by comma)", flagName)
+		}
+		var addrs []eth.Address
+		for _, a := range strings.Split(parts[0], "+") {
+			if a != "" {
+				addr, err := eth.NewAddress(a)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid address %q: %w", flagName, a, err)
+				}
+				addrs = append(addrs, addr)
 			}
-			parts := strings.Split(filter, ":")
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("option --log-filters must be of type address_hash+address_hash+address_hash:event_sig_hash+event_sig_hash (repeated, separated by comma)")
+		}
+		var sigs []eth.Hash
+		for _, s := range strings.Split(parts[1], "+") {
+			if s != "" {
+				sig, err := parseEventSignature(s)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid event signature %q: %w", flagName, s, err)
+				}
+				sigs = append(sigs, sig)
 			}
-			var addrs []eth.Address
-			for _, a := range strings.Split(parts[0], "+") {
-				if a != "" {
-					addr := eth.MustNewAddress(a)
-					addrs = append(addrs, addr)
+		}
+
+		logFilter := basicLogFilter(addrs, sigs)
+		for i, part := range parts[2:] {
+			var topics [][]byte
+			for _, t := range strings.Split(part, "+") {
+				if t != "" {
+					topic, err := eth.NewHash(t)
+					if err != nil {
+						return nil, fmt.Errorf("option --%s: invalid topic %q: %w", flagName, t, err)
+					}
+					topics = append(topics, topic.Bytes())
 				}
 			}
-			var sigs []eth.Hash
-			for _, s := range strings.Split(parts[1], "+") {
-				if s != "" {
-					sig := eth.MustNewHash(s)
-					sigs = append(sigs, sig)
+
+			switch i {
+			case 0:
+				logFilter.Topic1 = topics
+			case 1:
+				logFilter.Topic2 = topics
+			case 2:
+				logFilter.Topic3 = topics
+			}
+		}
+
+		out = append(out, logFilter)
+	}
+
+	return out, nil
+}
+
+func parseTransactionFilters(flagName string, transactionFilters string) (out []*pbtransform.TransactionFilter, err error) {
+	filters, err := splitTopLevel(transactionFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("option --%s must be of type from_address+from_address:to_address+to_address:method_sig+method_sig (repeated, separated by comma)", flagName)
+		}
+
+		pbFilter := &pbtransform.TransactionFilter{}
+		if pbFilter.From, err = parseLooseAddresses(flagName, parts[0]); err != nil {
+			return nil, err
+		}
+		if pbFilter.To, err = parseLooseAddresses(flagName, parts[1]); err != nil {
+			return nil, err
+		}
+		for _, s := range strings.Split(parts[2], "+") {
+			if s != "" {
+				sig, err := parseMethodSignature(s)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid method signature %q: %w", flagName, s, err)
 				}
+				pbFilter.Signatures = append(pbFilter.Signatures, sig)
 			}
+		}
+
+		out = append(out, pbFilter)
+	}
 
-			mf.LogFilters = append(mf.LogFilters, basicLogFilter(addrs, sigs))
+	return out, nil
+}
+
+// parseLooseAddresses parses the '+' separated addresses of a filter, each one being read like
+// eth.NewAddressLoose does
+func parseLooseAddresses(flagName string, in string) (out [][]byte, err error) {
+	for _, a := range strings.Split(in, "+") {
+		if a == "" {
+			continue
+		}
+
+		addr, err := eth.NewAddressLoose(a)
+		if err != nil {
+			return nil, fmt.Errorf("option --%s: invalid address %q: %w", flagName, a, err)
 		}
+		out = append(out, addr.Bytes())
 	}
+	return out, nil
+}
 
-	if sendAllBlockHeaders {
-		mf.SendAllBlockHeaders = true
+func parseBalanceChangeFilters(flagName string, balanceChangeFilters string) (out []*pbtransform.BalanceChangeFilter, err error) {
+	filters, err := splitTopLevel(balanceChangeFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
 	}
-	return mf, nil
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("option --%s must be of type address_hash+address_hash:reason+reason (repeated, separated by comma)", flagName)
+		}
+
+		pbFilter := &pbtransform.BalanceChangeFilter{}
+		if pbFilter.Addresses, err = parseLooseAddresses(flagName, parts[0]); err != nil {
+			return nil, err
+		}
+		for _, r := range strings.Split(parts[1], "+") {
+			if r != "" {
+				reason, found := pbeth.BalanceChange_Reason_value["REASON_"+strings.TrimPrefix(strings.ToUpper(r), "REASON_")]
+				if !found {
+					return nil, fmt.Errorf("option --%s: unknown balance change reason %q", flagName, r)
+				}
+				pbFilter.Reasons = append(pbFilter.Reasons, pbeth.BalanceChange_Reason(reason))
+			}
+		}
+
+		out = append(out, pbFilter)
+	}
+
+	return out, nil
+}
+
+func parseStorageChangeFilters(flagName string, storageChangeFilters string) (out []*pbtransform.StorageChangeFilter, err error) {
+	filters, err := splitTopLevel(storageChangeFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("option --%s must be of type address_hash+address_hash:slot_key+slot_key (repeated, separated by comma)", flagName)
+		}
+
+		pbFilter := &pbtransform.StorageChangeFilter{}
+		if pbFilter.Addresses, err = parseLooseAddresses(flagName, parts[0]); err != nil {
+			return nil, err
+		}
+		for _, k := range strings.Split(parts[1], "+") {
+			if k == "" {
+				continue
+			}
+
+			// Slot keys are always 32 bytes, a shorter or longer key would never match
+			key, err := eth.NewHash(k)
+			if err != nil {
+				return nil, fmt.Errorf("option --%s: invalid slot key %q: %w", flagName, k, err)
+			}
+			if len(key) != 32 {
+				return nil, fmt.Errorf("option --%s: invalid slot key %q, must be 32 bytes, got %d bytes", flagName, k, len(key))
+			}
+			pbFilter.Keys = append(pbFilter.Keys, key.Bytes())
+		}
+
+		out = append(out, pbFilter)
+	}
+
+	return out, nil
 }
 
 func basicCallToFilter(addrs []eth.Address, sigs []eth.Hash) *pbtransform.CallToFilter {
@@ -151,3 +635,77 @@ func basicLogFilter(addrs []eth.Address, sigs []eth.Hash) *pbtransform.LogFilter
 		EventSignatures: sigsBytes,
 	}
 }
+
+func parseContractCreationFilters(flagName string, contractCreationFilters string) (out []*pbtransform.ContractCreationFilter, err error) {
+	filters, err := splitTopLevel(contractCreationFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("option --%s must be of type deployer_address+deployer_address:code_hash_prefix+code_hash_prefix (repeated, separated by comma)", flagName)
+		}
+
+		pbFilter := &pbtransform.ContractCreationFilter{}
+		if pbFilter.Deployers, err = parseLooseAddresses(flagName, parts[0]); err != nil {
+			return nil, err
+		}
+		for _, p := range strings.Split(parts[1], "+") {
+			if p != "" {
+				prefix, err := eth.NewHex(p)
+				if err != nil {
+					return nil, fmt.Errorf("option --%s: invalid code hash prefix %q: %w", flagName, p, err)
+				}
+				pbFilter.CodeHashPrefixes = append(pbFilter.CodeHashPrefixes, prefix.Bytes())
+			}
+		}
+
+		out = append(out, pbFilter)
+	}
+
+	return out, nil
+}
+
+func parseValueTransferFilters(flagName string, valueTransferFilters string) (out []*pbtransform.ValueTransferFilter, err error) {
+	filters, err := splitTopLevel(valueTransferFilters, ',')
+	if err != nil {
+		return nil, fmt.Errorf("option --%s: %w", flagName, err)
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		parts := strings.Split(filter, ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("option --%s must be of type address+address:from_address+from_address:to_address+to_address:min_value_wei (repeated, separated by comma)", flagName)
+		}
+
+		pbFilter := &pbtransform.ValueTransferFilter{}
+		if pbFilter.Addresses, err = parseLooseAddresses(flagName, parts[0]); err != nil {
+			return nil, err
+		}
+		if pbFilter.From, err = parseLooseAddresses(flagName, parts[1]); err != nil {
+			return nil, err
+		}
+		if pbFilter.To, err = parseLooseAddresses(flagName, parts[2]); err != nil {
+			return nil, err
+		}
+		if parts[3] != "" {
+			minValue, ok := new(big.Int).SetString(parts[3], 10)
+			if !ok || minValue.Sign() < 0 {
+				return nil, fmt.Errorf("option --%s: invalid min value %q, must be a positive amount of wei in base 10", flagName, parts[3])
+			}
+			pbFilter.MinValue = pbeth.BigIntFromNative(minValue)
+		}
+
+		out = append(out, pbFilter)
+	}
+
+	return out, nil
+}
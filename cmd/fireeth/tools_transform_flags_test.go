@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_filterGroups(t *testing.T) {
+	router := eth.MustNewAddress("0x3333333333333333333333333333333333333333")
+	pool := eth.MustNewAddress("0x4444444444444444444444444444444444444444")
+	swap := eth.MustNewHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822")
+
+	groups := newFilterGroups()
+	callFilters, err := groups.split("swaps="+router.Pretty()+":,"+pool.Pretty()+":", func(g *pbtransform.FilterGroup, value string) (err error) {
+		g.CallFilters, err = parseCallFilters("call-filters", value)
+		return err
+	})
+	require.NoError(t, err)
+	logFilters, err := groups.split("swaps="+pool.Pretty()+":"+swap.Pretty(), func(g *pbtransform.FilterGroup, value string) (err error) {
+		g.LogFilters, err = parseLogFilters("log-filters", value)
+		return err
+	})
+
+	require.NoError(t, err)
+
+	assert.Equal(t, pool.Pretty()+":", callFilters)
+	assert.Equal(t, "", logFilters)
+
+	out, err := groups.parse()
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	require.Len(t, out[0].CallFilters, 1)
+	require.Len(t, out[0].LogFilters, 1)
+	assert.Equal(t, router.Bytes(), out[0].CallFilters[0].Addresses[0])
+	assert.Equal(t, swap.Bytes(), out[0].LogFilters[0].EventSignatures[0])
+}
+
+func newTransformFlagsTestCmd(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("header-only", false, "")
+	registerFilterFlags(cmd.Flags())
+	cmd.Flags().String("drop-fields", "", "")
+	cmd.Flags().Bool("drop-heavy-fields", false, "")
+	cmd.Flags().Bool("transactions-only", false, "")
+	cmd.Flags().Bool("logs-only", false, "")
+	cmd.Flags().Bool("include-reverted-logs", false, "")
+
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return cmd
+}
+
+func Test_parseTransformFlags_LogsOnly(t *testing.T) {
+	pool := eth.MustNewAddress("0x4444444444444444444444444444444444444444")
+	swap := eth.MustNewHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822")
+
+	transforms, err := parseTransformFlags(newTransformFlagsTestCmd(t, map[string]string{
+		"log-filters": pool.Pretty() + ":" + swap.Pretty(),
+		"logs-only":   "true",
+	}), zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+
+	logsOnly := &pbtransform.LogsOnly{}
+	require.NoError(t, transforms[1].UnmarshalTo(logsOnly))
+	require.Len(t, logsOnly.LogFilters, 1)
+	assert.Equal(t, [][]byte{pool.Bytes()}, logsOnly.LogFilters[0].Addresses)
+
+	_, err = parseTransformFlags(newTransformFlagsTestCmd(t, map[string]string{
+		"log-filters": "swaps=" + pool.Pretty() + ":" + swap.Pretty(),
+		"logs-only":   "true",
+	}), zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support grouped log filters")
+
+	_, err = parseTransformFlags(newTransformFlagsTestCmd(t, map[string]string{
+		"log-filters": pool.Pretty() + ":" + swap.Pretty() + ":0xzz",
+	}), zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid topic "0xzz"`)
+}
+
+func Test_parseFilters_PruneCallsWithFiltersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+call_filters:
+  - addresses: ["0x3333333333333333333333333333333333333333"]
+`), 0644))
+
+	filters, err := parseFilters(newTransformFlagsTestCmd(t, map[string]string{
+		"filters-file": path,
+		"prune-calls":  "true",
+	}))
+	require.NoError(t, err)
+	require.Len(t, filters.CallFilters, 1)
+	assert.True(t, filters.PruneCalls)
+
+	filters, err = parseFilters(newTransformFlagsTestCmd(t, map[string]string{"filters-file": path}))
+	require.NoError(t, err)
+	assert.False(t, filters.PruneCalls)
+}
+
+func Test_parseFilterFlags_InvalidValues(t *testing.T) {
+	tests := []struct {
+		flag          string
+		value         string
+		expectedError string
+	}{
+		{"balance-change-filters", "0xzz:withdrawal", `option --balance-change-filters: invalid address "0xzz"`},
+		{"balance-change-filters", ":bogus", `option --balance-change-filters: unknown balance change reason "bogus"`},
+		{"storage-change-filters", "0xzz:", `option --storage-change-filters: invalid address "0xzz"`},
+		{"storage-change-filters", ":0xzz", `option --storage-change-filters: invalid slot key "0xzz"`},
+		{"storage-change-filters", ":0x01", `option --storage-change-filters: invalid slot key "0x01", must be 32 bytes, got 1 bytes`},
+		{"contract-creation-filters", "0xzz:", `option --contract-creation-filters: invalid address "0xzz"`},
+		{"value-transfer-filters", "::0xzz:", `option --value-transfer-filters: invalid address "0xzz"`},
+		{"transaction-filters", "0xzz::", `option --transaction-filters: invalid address "0xzz"`},
+		{"transaction-filters", ":0xzz:", `option --transaction-filters: invalid address "0xzz"`},
+		{"call-filters", "0xzz:", `option --call-filters: invalid address "0xzz"`},
+		{"call-filters", "g=0xzz:", `filter group "g": option --call-filters: invalid address "0xzz"`},
+		{"balance-change-filters", "g=:withdrawal", `option --balance-change-filters does not accept filter groups`},
+		{"value-transfer-filters", "g=:::", `option --value-transfer-filters does not accept filter groups`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.flag+" "+test.value, func(t *testing.T) {
+			_, err := parseFilterFlags(newTransformFlagsTestCmd(t, map[string]string{test.flag: test.value}))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
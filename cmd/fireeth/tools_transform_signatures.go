@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/streamingfast/eth-go"
+)
+
+// parseMethodSignature accepts either a 4 bytes method selector in hex or a textual method
+// signature like 'transfer(address,uint256)', returning the selector
+func parseMethodSignature(in string) ([]byte, error) {
+	if !strings.Contains(in, "(") {
+		return eth.NewHex(in)
+	}
+
+	signature, err := canonicalSignature(in)
+	if err != nil {
+		return nil, err
+	}
+	return eth.Keccak256([]byte(signature))[0:4], nil
+}
+
+// parseEventSignature accepts either a 32 bytes event signature hash in hex or a textual event
+// signature like 'Transfer(address,indexed address,uint256)', returning the signature hash (topic.0)
+func parseEventSignature(in string) ([]byte, error) {
+	if !strings.Contains(in, "(") {
+		hash, err := eth.NewHash(in)
+		return hash, err
+	}
+
+	signature, err := canonicalSignature(in)
+	if err != nil {
+		return nil, err
+	}
+	return eth.Keccak256([]byte(signature)), nil
+}
+
+// canonicalSignature turns a textual signature into its canonical form used for hashing, dropping
+// parameter names, 'indexed' keywords and data locations and expanding 'uint'/'int' aliases, so
+// that 'Transfer(address indexed from, address indexed to, uint value)' becomes
+// 'Transfer(address,address,uint256)'
+func canonicalSignature(in string) (string, error) {
+	in = strings.TrimSpace(in)
+	open := strings.Index(in, "(")
+	if open <= 0 || !strings.HasSuffix(in, ")") {
+		return "", fmt.Errorf("invalid signature %q: expected 'name(type1,type2,...)'", in)
+	}
+
+	name := strings.TrimSpace(in[:open])
+	params, err := canonicalParameters(in[open+1 : len(in)-1])
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %w", in, err)
+	}
+	return name + "(" + params + ")", nil
+}
+
+func canonicalParameters(in string) (string, error) {
+	if strings.TrimSpace(in) == "" {
+		return "", nil
+	}
+
+	params, err := splitTopLevel(in, ',')
+	if err != nil {
+		return "", err
+	}
+
+	types := make([]string, len(params))
+	for i, param := range params {
+		if types[i], err = canonicalType(param); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(types, ","), nil
+}
+
+func canonicalType(param string) (string, error) {
+	param = strings.TrimSpace(param)
+	param = strings.TrimSpace(strings.TrimPrefix(param, "indexed "))
+
+	// Tuple, possibly an array of tuples, e.g. '(address,uint256)[] orders'
+	if strings.HasPrefix(param, "(") {
+		close, err := matchingParenthesis(param)
+		if err != nil {
+			return "", err
+		}
+
+		inner, err := canonicalParameters(param[1:close])
+		if err != nil {
+			return "", err
+		}
+
+		suffix := strings.Fields(param[close+1:] + " ")
+		arrays := ""
+		if len(suffix) != 0 && strings.HasPrefix(suffix[0], "[") {
+			arrays = suffix[0]
+		}
+		return "(" + inner + ")" + arrays, nil
+	}
+
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty parameter")
+	}
+
+	typeName := fields[0]
+	switch {
+	case typeName == "uint" || strings.HasPrefix(typeName, "uint["):
+		typeName = "uint256" + strings.TrimPrefix(typeName, "uint")
+	case typeName == "int" || strings.HasPrefix(typeName, "int["):
+		typeName = "int256" + strings.TrimPrefix(typeName, "int")
+	}
+	return typeName, nil
+}
+
+func matchingParenthesis(in string) (int, error) {
+	depth := 0
+	for i, c := range in {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced parenthesis in %q", in)
+}
+
+// splitTopLevel splits in on sep, ignoring the separators enclosed in parenthesis so that
+// textual signatures can be used in comma separated lists
+func splitTopLevel(in string, sep rune) (out []string, err error) {
+	depth := 0
+	start := 0
+	for i, c := range in {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parenthesis in %q", in)
+			}
+		case sep:
+			if depth == 0 {
+				out = append(out, in[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parenthesis in %q", in)
+	}
+
+	return append(out, in[start:]), nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func Test_parseSignatures(t *testing.T) {
+	transferEvent := eth.MustNewHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef").Bytes()
+	transferMethod := eth.MustNewHex("0xa9059cbb").Bytes()
+
+	for _, signature := range []string{
+		"Transfer(address,address,uint256)",
+		"Transfer(address,indexed address,uint256)",
+		"Transfer(address indexed from, address indexed to, uint value)",
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+	} {
+		hash, err := parseEventSignature(signature)
+		require.NoError(t, err, signature)
+		assert.Equal(t, transferEvent, hash, signature)
+	}
+
+	for _, signature := range []string{"transfer(address,uint256)", "transfer(address to, uint amount)", "0xa9059cbb"} {
+		selector, err := parseMethodSignature(signature)
+		require.NoError(t, err, signature)
+		assert.Equal(t, transferMethod, selector, signature)
+	}
+
+	canonical, err := canonicalSignature("fill((address maker, uint256[] amounts)[] orders, bytes calldata data)")
+	require.NoError(t, err)
+	assert.Equal(t, "fill((address,uint256[])[],bytes)", canonical)
+
+	_, err = parseEventSignature("Transfer(address,address")
+	assert.Error(t, err)
+}
+
+func Test_parseLogFilters_TextualSignatures(t *testing.T) {
+	filters, err := parseLogFilters("log-filters", "0xcccccccccccccccccccccccccccccccccccccccc:Transfer(address,indexed address,uint256)+Approval(address,address,uint256),:Sync(uint112,uint112)")
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	assert.Len(t, filters[0].EventSignatures, 2)
+	assert.Len(t, filters[1].EventSignatures, 1)
+}
+
+func Test_readFiltersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+callFilters:
+  - addresses: ["0x3333333333333333333333333333333333333333"]
+    signatures: ["transfer(address,uint256)", "0x095ea7b3"]
+    callTypes: [DELEGATE]
+log_filters:
+  - event_signatures: ["Transfer(address,indexed address,uint256)"]
+    topic2: ["0x000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"]
+value_transfer_filters:
+  - min_value: "1000000000000000000"
+prune_calls: true
+`), 0644))
+
+	filter, err := readFiltersFile(path)
+	require.NoError(t, err)
+
+	require.Len(t, filter.CallFilters, 1)
+	assert.Equal(t, [][]byte{eth.MustNewAddress("0x3333333333333333333333333333333333333333").Bytes()}, filter.CallFilters[0].Addresses)
+	assert.Equal(t, [][]byte{eth.MustNewHex("0xa9059cbb").Bytes(), eth.MustNewHex("0x095ea7b3").Bytes()}, filter.CallFilters[0].Signatures)
+	assert.Equal(t, []pbeth.CallType{pbeth.CallType_DELEGATE}, filter.CallFilters[0].CallTypes)
+
+	require.Len(t, filter.LogFilters, 1)
+	assert.Len(t, filter.LogFilters[0].EventSignatures[0], 32)
+	assert.Len(t, filter.LogFilters[0].Topic2[0], 32)
+
+	require.Len(t, filter.ValueTransferFilters, 1)
+	assert.Equal(t, "1000000000000000000", filter.ValueTransferFilters[0].MinValue.Native().String())
+	assert.True(t, filter.PruneCalls)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"unknownFilters": []}`), 0644))
+	_, err = readFiltersFile(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("callFilters:\n  - signatures: [0x095ea7b3]\n"), 0644))
+	_, err = readFiltersFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "callFilters[0].signatures[0]: expected a string, got the number 1.57198259e+08, hex values must be quoted")
+
+	require.NoError(t, os.WriteFile(path, []byte("value_transfer_filters:\n  - min_value: 9007199254740993\n"), 0644))
+	_, err = readFiltersFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be represented exactly as a number, it must be quoted as a decimal string")
+
+	require.NoError(t, os.WriteFile(path, []byte("value_transfer_filters:\n  - min_value: 1000\n"), 0644))
+	filter, err = readFiltersFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", filter.ValueTransferFilters[0].MinValue.Native().String())
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"go.uber.org/zap"
+)
+
+func newVerifyBlockHashesCmd(logger *zap.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-block-hashes <merged-blocks-store> <start-block> <stop-block>",
+		Short: "Checks that the hash of each merged block is the one recomputed from its header fields",
+		Long: cli.Dedent(`
+			The 'verify-block-hashes' command recomputes, for each block of [start-block, stop-block), the block
+			hash from the RLP encoding of its header fields and compares it to the block's hash, catching tampered
+			headers and conversion bugs.
+
+			By default, the fork rules used to encode the header are inferred from the fields set on it. Prague
+			support is partial: Prague headers also commit to the execution requests hash which blocks do not
+			record, a Cancun shaped header whose hash does not match is also checked as a Prague header without
+			execution requests. When neither matches, the block may be a Prague block with execution requests and
+			is reported as not verifiable instead of mismatching, it does not change the exit status. Use
+			'--fork=cancun' to report those blocks as mismatching on ranges known to be before Prague.
+		`),
+		Args: cobra.ExactArgs(3),
+		RunE: createVerifyBlockHashesE(logger),
+		Example: examplePrefixed("fireeth tools verify-block-hashes", `
+			# Verify the blocks of a range of Ethereum Mainnet
+			gs://bucket/eth-mainnet/merged-blocks 19000000 19001000
+
+			# Force the fork rules used to encode the headers
+			gs://bucket/eth-mainnet/merged-blocks 19000000 19001000 --fork=cancun
+		`),
+	}
+
+	cmd.Flags().String("fork", "auto", "Fork rules used to encode the headers, one of 'auto', 'legacy', 'london', 'shanghai', 'cancun' or 'prague' (assuming no execution requests)")
+	cmd.Flags().Bool("fail-fast", false, "Stop at the first block whose hash disagrees with its header fields")
+
+	return cmd
+}
+
+func createVerifyBlockHashesE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		mergedBlocksStore, err := dstore.NewDBinStore(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to create merged blocks store: %w", err)
+		}
+
+		start := mustParseUint64(args[1])
+		stop := mustParseUint64(args[2])
+		if stop <= start {
+			return fmt.Errorf("stop block must be greater than start block")
+		}
+
+		var forcedFork *pbeth.HeaderFork
+		if value := sflags.MustGetString(cmd, "fork"); value != "auto" {
+			fork, err := pbeth.ParseHeaderFork(value)
+			if err != nil {
+				return fmt.Errorf("invalid fork: %w", err)
+			}
+			forcedFork = &fork
+		}
+		failFast := sflags.MustGetBool(cmd, "fail-fast")
+
+		var verifiedCount, mismatchCount, unverifiableCount int
+		verify := func(block *pbeth.Block) error {
+			if block.Number < start || block.Number >= stop {
+				return nil
+			}
+
+			if block.Header == nil {
+				unverifiableCount++
+				fmt.Printf("Block #%d (%s): cannot verify: block has no header\n", block.Number, block.ID())
+				return nil
+			}
+
+			matches, computed, err := verifyBlockHash(block, forcedFork)
+			if err != nil {
+				unverifiableCount++
+				fmt.Printf("Block #%d (%s): cannot verify: %s\n", block.Number, block.ID(), err)
+				return nil
+			}
+
+			verifiedCount++
+			if matches {
+				return nil
+			}
+
+			mismatchCount++
+			fmt.Printf("Block #%d (%s): computed hash %x from header fields\n", block.Number, block.ID(), computed)
+
+			if failFast {
+				return fmt.Errorf("block #%d hash disagrees with its header fields", block.Number)
+			}
+			return nil
+		}
+
+		for base := start - start%100; base < stop; base += 100 {
+			logger.Debug("verifying merged blocks file", zap.Uint64("base_block_num", base))
+			if err := processMergedBlocksFile(ctx, mergedBlocksStore, base, verify); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("Verified %d blocks, %d with a hash disagreeing with their header fields, %d that could not be verified\n", verifiedCount, mismatchCount, unverifiableCount)
+		if mismatchCount != 0 {
+			return fmt.Errorf("found %d blocks whose hash disagrees with their header fields", mismatchCount)
+		}
+		return nil
+	}
+}
+
+// verifyBlockHash recomputes the block's hash from its header fields, using the forced fork when
+// set and the fork inferred from the header otherwise, returning the computed hash on mismatch. An
+// error is returned for the blocks that cannot be verified, like Prague blocks with execution requests.
+func verifyBlockHash(block *pbeth.Block, forcedFork *pbeth.HeaderFork) (matches bool, computed []byte, err error) {
+	if forcedFork != nil {
+		computed, err = block.Header.ComputeHash(*forcedFork, pbeth.EmptyRequestsHash)
+		if err != nil {
+			return false, nil, err
+		}
+		return bytes.Equal(computed, block.Hash), computed, nil
+	}
+
+	fork := block.Header.InferredFork()
+	computed, err = block.Header.ComputeHash(fork, nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !bytes.Equal(computed, block.Hash) && fork == pbeth.HeaderForkCancun {
+		prague, err := block.Header.ComputeHash(pbeth.HeaderForkPrague, pbeth.EmptyRequestsHash)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if bytes.Equal(prague, block.Hash) {
+			return true, prague, nil
+		}
+
+		// The header does not record its execution requests hash, a Prague block with execution
+		// requests cannot be told apart from a mismatching block
+		return false, nil, fmt.Errorf("hash matches neither a Cancun header nor a Prague header without execution requests, it may be a Prague block with execution requests, whose hash is not recorded")
+	}
+
+	return bytes.Equal(computed, block.Hash), computed, nil
+}
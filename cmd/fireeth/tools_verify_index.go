@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-ethereum/transform"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"go.uber.org/zap"
+)
+
+func newVerifyIndexCmd(logger *zap.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-index <merged-blocks-store> <index-store> <start-block> <stop-block>",
+		Short: "Checks the 'combined' index files covering a range against the keys rebuilt from the merged blocks",
+		Long: cli.Dedent(`
+			The 'verify-index' command rebuilds, from the merged blocks, the keys of each 'combined' index file
+			covering [start-block, stop-block) with the same logic as the index builder and compares them to the
+			stored bitmaps. Index files are verified in parallel, each one being reported as it is verified, in order.
+
+			A block missing from a stored bitmap is wrongly skipped by the filters on that key while an extra block
+			is only needlessly read. The 'combinedtrx' transaction positions index file next to each 'combined' one,
+			if any, is verified the same way, a transaction missing from it being wrongly skipped by the filters.
+			With '--rewrite', divergent index files are replaced by the rebuilt ones.
+		`),
+		Args: cobra.ExactArgs(4),
+		RunE: createVerifyIndexE(logger),
+		Example: examplePrefixed("fireeth tools verify-index", `
+			# Verify the index files covering the range
+			gs://bucket/eth-mainnet/merged-blocks gs://bucket/eth-mainnet/index 19000000 19100000
+
+			# Verify and rewrite the divergent index files, using 16 workers
+			gs://bucket/eth-mainnet/merged-blocks gs://bucket/eth-mainnet/index 19000000 19100000 --rewrite --workers=16
+		`),
+	}
+
+	cmd.Flags().Int("workers", 4, "Number of index files verified concurrently")
+	cmd.Flags().Bool("rewrite", false, "Replace the divergent index files by the ones rebuilt from the merged blocks")
+	cmd.Flags().Int("max-reported-keys", 10, "Maximum number of divergent keys printed for each divergent index file")
+	registerIndexBlockSizesFlag(cmd.Flags())
+
+	return cmd
+}
+
+type verifyIndexResult struct {
+	file  *transform.CombinedIndexFile
+	diffs []*transform.CombinedIndexKeyDiff
+
+	// positionsFile is the `combinedtrx` index file next to the `combined` one, nil when absent
+	positionsFile  *transform.CombinedIndexFile
+	positionsDiffs []*transform.CombinedIndexKeyDiff
+
+	err error
+}
+
+func createVerifyIndexE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		mergedBlocksStore, err := dstore.NewDBinStore(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to create merged blocks store: %w", err)
+		}
+
+		rewrite := sflags.MustGetBool(cmd, "rewrite")
+		indexStore, err := dstore.NewStore(args[1], "", "", rewrite)
+		if err != nil {
+			return fmt.Errorf("unable to create index store: %w", err)
+		}
+
+		start := mustParseUint64(args[2])
+		stop := mustParseUint64(args[3])
+		if stop <= start {
+			return fmt.Errorf("stop block must be greater than start block")
+		}
+
+		workers := sflags.MustGetInt(cmd, "workers")
+		if workers < 1 {
+			return fmt.Errorf("invalid workers value %d, must be at least 1", workers)
+		}
+		maxReportedKeys := sflags.MustGetInt(cmd, "max-reported-keys")
+
+		files, err := transform.ListCombinedIndexFiles(ctx, indexStore, start, stop, indexBlockSizes(cmd))
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no index file found covering [%d, %d)", start, stop)
+		}
+
+		// Each file gets its own result channel so that results are printed in order while
+		// the files are verified concurrently
+		results := make([]chan *verifyIndexResult, len(files))
+		jobs := make(chan int)
+		for i := range results {
+			results[i] = make(chan *verifyIndexResult, 1)
+		}
+
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					results[i] <- verifyIndexFile(ctx, logger, mergedBlocksStore, indexStore, files[i], rewrite)
+				}
+			}()
+		}
+
+		go func() {
+			defer close(jobs)
+			for i := range files {
+				select {
+				case jobs <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		var divergentCount int
+		for i := range files {
+			var result *verifyIndexResult
+			select {
+			case result = <-results[i]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if result.err != nil {
+				cancel()
+				wg.Wait()
+				return fmt.Errorf("verifying index file %q: %w", result.file.Filename, result.err)
+			}
+
+			if len(result.diffs) == 0 && len(result.positionsDiffs) == 0 {
+				fmt.Printf("%s: OK\n", result.file.Filename)
+				continue
+			}
+
+			divergentCount++
+			printIndexDiffs(result.file, result.diffs, maxReportedKeys, rewrite, blockNumsString)
+			if result.positionsFile != nil {
+				printIndexDiffs(result.positionsFile, result.positionsDiffs, maxReportedKeys, rewrite, transactionPositionsString)
+			}
+		}
+		wg.Wait()
+
+		fmt.Printf("Verified %d index files, %d divergent\n", len(files), divergentCount)
+		if divergentCount != 0 && !rewrite {
+			return fmt.Errorf("found %d divergent index files, use '--rewrite' to replace them", divergentCount)
+		}
+		return nil
+	}
+}
+
+// printIndexDiffs prints the divergent keys of the index file, formatting their missing and extra
+// values (block numbers or transaction positions) with valuesString
+func printIndexDiffs(file *transform.CombinedIndexFile, diffs []*transform.CombinedIndexKeyDiff, maxReportedKeys int, rewritten bool, valuesString func(values []uint64, limit int) string) {
+	if len(diffs) == 0 {
+		fmt.Printf("%s: OK\n", file.Filename)
+		return
+	}
+
+	var missingCount, extraCount int
+	for _, diff := range diffs {
+		missingCount += len(diff.MissingBlocks)
+		extraCount += len(diff.ExtraBlocks)
+	}
+
+	status := "DIVERGENT"
+	if rewritten {
+		status = "REWRITTEN"
+	}
+	fmt.Printf("%s: %s, %d keys differ, %d key/value pairs missing, %d key/value pairs extra\n", file.Filename, status, len(diffs), missingCount, extraCount)
+
+	for i, diff := range diffs {
+		if i >= maxReportedKeys {
+			fmt.Printf("  ... and %d more keys\n", len(diffs)-maxReportedKeys)
+			break
+		}
+
+		kind, value := transform.CombinedIndexKeyKind(diff.Key)
+		if kind == "" {
+			kind = "unknown"
+		}
+
+		var parts []string
+		if len(diff.MissingBlocks) != 0 {
+			parts = append(parts, fmt.Sprintf("missing %s", valuesString(diff.MissingBlocks, 5)))
+		}
+		if len(diff.ExtraBlocks) != 0 {
+			parts = append(parts, fmt.Sprintf("extra %s", valuesString(diff.ExtraBlocks, 5)))
+		}
+		fmt.Printf("  %s %s: %s\n", kind, value, strings.Join(parts, ", "))
+	}
+}
+
+func blockNumsString(blockNums []uint64, limit int) string {
+	return "blocks " + valuesString(blockNums, limit, func(blockNum uint64) string {
+		return fmt.Sprintf("%d", blockNum)
+	})
+}
+
+func transactionPositionsString(positions []uint64, limit int) string {
+	return "transactions " + valuesString(positions, limit, func(position uint64) string {
+		blockNum, index := transform.TransactionIndex(position)
+		return fmt.Sprintf("%d#%d", blockNum, index)
+	})
+}
+
+func valuesString(values []uint64, limit int, format func(value uint64) string) string {
+	var out []string
+	for i, value := range values {
+		if i >= limit {
+			out = append(out, fmt.Sprintf("... (%d total)", len(values)))
+			break
+		}
+		out = append(out, format(value))
+	}
+	return strings.Join(out, ",")
+}
+
+// verifyIndexFile rebuilds the index file from the merged blocks and compares it to the stored one,
+// along with the `combinedtrx` index file next to it, if any, whose positions must agree with the
+// rebuilt index for the filters to emit the matching transactions
+func verifyIndexFile(ctx context.Context, logger *zap.Logger, mergedBlocksStore, indexStore dstore.Store, file *transform.CombinedIndexFile, rewrite bool) *verifyIndexResult {
+	result := &verifyIndexResult{file: file}
+
+	actual, err := transform.ReadCombinedIndexBundle(ctx, indexStore, file)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	expected := transform.NewCombinedIndexBundle(file)
+	indexer := &transform.EthCombinedIndexer{BlockIndexer: expected}
+
+	expectedPositions := transform.NewTransactionPositionsBundle(file)
+	actualPositions, err := transform.ReadCombinedIndexBundle(ctx, indexStore, expectedPositions.CombinedIndexFile)
+	switch {
+	case err == nil:
+		result.positionsFile = expectedPositions.CombinedIndexFile
+		indexer.TransactionPositions = expectedPositions
+	case !errors.Is(err, dstore.ErrNotFound):
+		result.err = err
+		return result
+	}
+
+	for base := file.LowBlockNum - file.LowBlockNum%100; base < file.ExclusiveHighBlockNum(); base += 100 {
+		if err := processMergedBlocksFile(ctx, mergedBlocksStore, base, indexer.ProcessBlock); err != nil {
+			result.err = err
+			return result
+		}
+	}
+
+	result.diffs = expected.Diff(actual)
+	if result.positionsFile != nil {
+		result.positionsDiffs = expectedPositions.Diff(actualPositions)
+	}
+	logger.Debug("verified index file", zap.String("filename", file.Filename), zap.Int("divergent_key_count", len(result.diffs)), zap.Int("divergent_positions_key_count", len(result.positionsDiffs)))
+
+	if !rewrite {
+		return result
+	}
+
+	if len(result.diffs) != 0 {
+		if err := expected.Write(ctx, indexStore); err != nil {
+			result.err = err
+			return result
+		}
+	}
+	if len(result.positionsDiffs) != 0 {
+		if err := expectedPositions.Write(ctx, indexStore); err != nil {
+			result.err = err
+		}
+	}
+	return result
+}
+
+// processMergedBlocksFile calls process on each block of the merged blocks file starting at base
+func processMergedBlocksFile(ctx context.Context, store dstore.Store, base uint64, process func(block *pbeth.Block) error) error {
+	filename := fmt.Sprintf("%010d", base)
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return fmt.Errorf("open merged blocks file %q: %w", filename, err)
+	}
+	defer reader.Close()
+
+	blockReader, err := bstream.NewDBinBlockReader(reader)
+	if err != nil {
+		return fmt.Errorf("creating block reader of %q: %w", filename, err)
+	}
+
+	for {
+		block, err := blockReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading block of %q: %w", filename, err)
+		}
+
+		ethBlock := &pbeth.Block{}
+		if err := block.Payload.UnmarshalTo(ethBlock); err != nil {
+			return fmt.Errorf("unmarshaling eth block %d: %w", block.Number, err)
+		}
+
+		if err := process(ethBlock); err != nil {
+			return fmt.Errorf("processing block %d: %w", block.Number, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/firehose-ethereum/codec"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"go.uber.org/zap"
+)
+
+func newVerifyRootsCmd(logger *zap.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-roots <merged-blocks-store> <start-block> <stop-block>",
+		Short: "Checks the logs bloom, transactions root and receipts root of the headers of merged blocks against their content",
+		Long: cli.Dedent(`
+			The 'verify-roots' command recomputes, for each block of [start-block, stop-block), the logs bloom,
+			the transactions trie root and the receipts trie root from the block's transactions and receipts and
+			compares them to the ones of the block's header.
+
+			The chain ID is needed to encode typed transactions, when not provided it is inferred from the block's
+			EIP-155 legacy transactions. Blocks with transaction types specific to a chain (e.g. Arbitrum or Optimism)
+			cannot be verified and are reported as such. So are 'BASE' blocks with a contract creation, their traces
+			having no calls to tell a creation, whose signed recipient is empty, apart from a call to the created address.
+
+			The node variant that produced the blocks (e.g. 'polygon') must be provided for the transactions added by
+			Firehose that are not part of the chain's consensus, like the Polygon state sync transaction, to be ignored.
+		`),
+		Args: cobra.ExactArgs(3),
+		RunE: createVerifyRootsE(logger),
+		Example: examplePrefixed("fireeth tools verify-roots", `
+			# Verify the blocks of a range of Ethereum Mainnet
+			gs://bucket/eth-mainnet/merged-blocks 19000000 19001000 --chain-id=1
+
+			# Verify the blocks of a range of Polygon Mainnet
+			gs://bucket/polygon-mainnet/merged-blocks 55000000 55001000 --chain-id=137 --node-variant=polygon
+		`),
+	}
+
+	cmd.Flags().Uint64("chain-id", 0, "Chain ID used to encode typed transactions, inferred from the blocks' legacy transactions when 0")
+	cmd.Flags().String("node-variant", "", "Variant of the node that produced the blocks (e.g. 'polygon'), as announced by its 'FIRE INIT' line, used to ignore the transactions it adds that are not part of the chain's consensus")
+	cmd.Flags().Bool("fail-fast", false, "Stop at the first block whose header commitments disagree with its content")
+
+	return cmd
+}
+
+func createVerifyRootsE(logger *zap.Logger) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		mergedBlocksStore, err := dstore.NewDBinStore(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to create merged blocks store: %w", err)
+		}
+
+		start := mustParseUint64(args[1])
+		stop := mustParseUint64(args[2])
+		if stop <= start {
+			return fmt.Errorf("stop block must be greater than start block")
+		}
+
+		var chainID *big.Int
+		if value := sflags.MustGetUint64(cmd, "chain-id"); value != 0 {
+			chainID = new(big.Int).SetUint64(value)
+		}
+		nodeVariant := sflags.MustGetString(cmd, "node-variant")
+		failFast := sflags.MustGetBool(cmd, "fail-fast")
+
+		var verifiedCount, mismatchCount, unverifiableCount int
+		verify := func(block *pbeth.Block) error {
+			if block.Number < start || block.Number >= stop {
+				return nil
+			}
+
+			mismatches, err := codec.VerifyHeaderCommitments(block, chainID, nodeVariant)
+			if err != nil {
+				unverifiableCount++
+				fmt.Printf("Block #%d (%s): cannot verify: %s\n", block.Number, block.ID(), err)
+				return nil
+			}
+
+			verifiedCount++
+			if len(mismatches) == 0 {
+				return nil
+			}
+
+			mismatchCount++
+			for _, mismatch := range mismatches {
+				fmt.Printf("Block #%d (%s): %s\n", block.Number, block.ID(), mismatch)
+			}
+
+			if failFast {
+				return fmt.Errorf("block #%d header commitments disagree with its content", block.Number)
+			}
+			return nil
+		}
+
+		for base := start - start%100; base < stop; base += 100 {
+			logger.Debug("verifying merged blocks file", zap.Uint64("base_block_num", base))
+			if err := processMergedBlocksFile(ctx, mergedBlocksStore, base, verify); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("Verified %d blocks, %d with mismatching header commitments, %d that could not be verified\n", verifiedCount, mismatchCount, unverifiableCount)
+		if mismatchCount != 0 {
+			return fmt.Errorf("found %d blocks whose header commitments disagree with their content", mismatchCount)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package codec
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"go.uber.org/zap"
+)
+
+// pipelinedLine is a line read by the blockLinePipeline, `FIRE BLOCK` lines being decoded
+// concurrently, the decoded block being available once `done` is closed
+type pipelinedLine struct {
+	line string
+
+	// done is nil for lines that are not `FIRE BLOCK` ones, those are handled by the console reader
+	done       chan struct{}
+	block      *pbbstream.Block
+	mismatches []payloadMismatch
+	err        error
+}
+
+func (l *pipelinedLine) wait() (*pbbstream.Block, error) {
+	<-l.done
+	return l.block, l.err
+}
+
+// blockLinePipeline reads the lines of an instrumented node using Firehose protocol 3 and decodes
+// the `FIRE BLOCK` ones on multiple workers while returning the lines in the order they were
+// read. At most `maxInFlight` lines are read ahead of the console reader, which bounds the memory
+// held by the pipeline to that many lines and decoded blocks.
+type blockLinePipeline struct {
+	output chan *pipelinedLine
+	work   chan *pipelinedLine
+
+	decodeLine func(line string) (*pbbstream.Block, []payloadMismatch, error)
+
+	cancel context.CancelFunc
+	logger *zap.Logger
+}
+
+func newBlockLinePipeline(lines chan string, workers int, maxInFlight int, decodeLine func(line string) (*pbbstream.Block, []payloadMismatch, error), logger *zap.Logger) *blockLinePipeline {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &blockLinePipeline{
+		output: make(chan *pipelinedLine, maxInFlight),
+		work:   make(chan *pipelinedLine),
+
+		decodeLine: decodeLine,
+
+		cancel: cancel,
+		logger: logger,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.decode()
+		}()
+	}
+
+	go func() {
+		p.dispatch(ctx, lines)
+		close(p.work)
+		wg.Wait()
+	}()
+
+	return p
+}
+
+func (p *blockLinePipeline) dispatch(ctx context.Context, lines chan string) {
+	defer close(p.output)
+
+	for line := range lines {
+		pipelined := &pipelinedLine{line: line}
+		if strings.HasPrefix(line, "FIRE BLOCK") {
+			pipelined.done = make(chan struct{})
+		}
+
+		// The output channel being bounded, this blocks when the console reader is `maxInFlight`
+		// lines behind, which is what bounds the memory used by the pipeline
+		select {
+		case p.output <- pipelined:
+		case <-ctx.Done():
+			return
+		}
+
+		if pipelined.done != nil {
+			select {
+			case p.work <- pipelined:
+			case <-ctx.Done():
+				pipelined.err = ctx.Err()
+				close(pipelined.done)
+				return
+			}
+		}
+	}
+
+	p.logger.Info("lines channel has been closed, block line pipeline completed")
+}
+
+func (p *blockLinePipeline) decode() {
+	for pipelined := range p.work {
+		start := time.Now()
+		pipelined.block, pipelined.mismatches, pipelined.err = p.decodeLine(pipelined.line[len("FIRE "):])
+		BlockTotalParseTime.AddInt64(int64(time.Since(start)))
+
+		close(pipelined.done)
+	}
+}
+
+// next returns the next line read, ok being false once all lines have been read
+func (p *blockLinePipeline) next() (line *pipelinedLine, ok bool) {
+	line, ok = <-p.output
+	return
+}
+
+func (p *blockLinePipeline) close() {
+	p.cancel()
+}
@@ -0,0 +1,138 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+// HeaderCommitmentMismatch is a commitment of the block's header disagreeing with the one
+// recomputed from the block's content
+type HeaderCommitmentMismatch struct {
+	Field    string
+	Header   []byte
+	Computed []byte
+}
+
+func (m *HeaderCommitmentMismatch) String() string {
+	return fmt.Sprintf("%s (header %x, computed %x)", m.Field, m.Header, m.Computed)
+}
+
+// VerifyHeaderCommitments recomputes the logs bloom, the transactions root and the receipts
+// root of the block and returns the ones disagreeing with the block's header.
+//
+// The chain ID is needed to encode typed transactions, when nil it is inferred from the block's
+// EIP-155 legacy transactions, an error being returned if the block has typed transactions but
+// no such legacy transaction. The node variant is the one announced by the `FIRE INIT` line of the
+// node that produced the block (e.g. `polygon`, empty when unknown), the transactions added by
+// Firehose for this variant that are not part of the chain's consensus (e.g. the Polygon state
+// sync transaction) are ignored.
+func VerifyHeaderCommitments(block *pbeth.Block, chainID *big.Int, nodeVariant string) ([]*HeaderCommitmentMismatch, error) {
+	if block.Header == nil {
+		return nil, fmt.Errorf("block #%d has no header", block.Number)
+	}
+
+	traces := consensusTransactionTraces(block, nodeVariant)
+	if chainID == nil {
+		chainID = inferChainID(traces)
+	}
+
+	transactionsRoot, err := pbeth.TransactionsRoot(traces, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("computing transactions root: %w", err)
+	}
+
+	receiptsRoot, err := pbeth.ReceiptsRoot(traces)
+	if err != nil {
+		return nil, fmt.Errorf("computing receipts root: %w", err)
+	}
+
+	var logs []*pbeth.Log
+	for _, trace := range traces {
+		logs = append(logs, trace.Receipt.Logs...)
+	}
+
+	var mismatches []*HeaderCommitmentMismatch
+	check := func(field string, header, computed []byte) {
+		if !bytes.Equal(header, computed) {
+			mismatches = append(mismatches, &HeaderCommitmentMismatch{Field: field, Header: header, Computed: computed})
+		}
+	}
+
+	check("logs_bloom", block.Header.LogsBloom, computeLogsBloom(logs))
+	check("transactions_root", block.Header.TransactionsRoot, transactionsRoot)
+	check("receipt_root", block.Header.ReceiptRoot, receiptsRoot)
+
+	return mismatches, nil
+}
+
+func consensusTransactionTraces(block *pbeth.Block, nodeVariant string) []*pbeth.TransactionTrace {
+	if !strings.EqualFold(nodeVariant, "polygon") {
+		return block.TransactionTraces
+	}
+
+	polygonHash := computePolygonHash(block.Number, block.Hash)
+
+	out := make([]*pbeth.TransactionTrace, 0, len(block.TransactionTraces))
+	for _, trace := range block.TransactionTraces {
+		if bytes.Equal(trace.Hash, polygonHash) {
+			continue
+		}
+		out = append(out, trace)
+	}
+	return out
+}
+
+// inferChainID returns the chain ID signed in the first EIP-155 legacy transaction, nil if
+// there is none
+func inferChainID(traces []*pbeth.TransactionTrace) *big.Int {
+	for _, trace := range traces {
+		if trace.Type != pbeth.TransactionTrace_TRX_TYPE_LEGACY {
+			continue
+		}
+
+		// EIP-155 signatures have v = chain_id * 2 + 35 or chain_id * 2 + 36
+		v := new(big.Int).SetBytes(trace.V)
+		if v.Cmp(big.NewInt(35)) < 0 {
+			continue
+		}
+
+		return v.Sub(v, big.NewInt(35)).Rsh(v, 1)
+	}
+	return nil
+}
+
+// checkHeaderCommitments is the strict mode of the reader, returning an error when the block's
+// header commitments disagree with its content, it only reads the reader's configuration so
+// that blocks can be checked concurrently
+func (ctx *parseCtx) checkHeaderCommitments(block *pbeth.Block) error {
+	mismatches, err := VerifyHeaderCommitments(block, ctx.chainID, ctx.nodeVariant)
+	if err != nil {
+		return fmt.Errorf("verifying block #%d header commitments: %w", block.Number, err)
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	var descriptions []string
+	for _, mismatch := range mismatches {
+		HeaderCommitmentMismatchCount.Inc(mismatch.Field)
+		descriptions = append(descriptions, mismatch.String())
+	}
+
+	return fmt.Errorf("block #%d (%s) header commitments disagree with its content: %s", block.Number, block.ID(), strings.Join(descriptions, ", "))
+}
+
+// WithStrictHeaderCommitments makes the console reader return an error for a block whose logs
+// bloom, transactions root or receipts root disagree with its content, see VerifyHeaderCommitments
+// for the chain ID
+func WithStrictHeaderCommitments(chainID *big.Int) ConsoleReaderOption {
+	return func(c *ConsoleReader) {
+		c.ctx.strictHeaderCommitments = true
+		c.ctx.chainID = chainID
+	}
+}
@@ -0,0 +1,95 @@
+package codec
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHeaderCommitments(t *testing.T) {
+	cr := testFileConsoleReader(t, "testdata/firehose-logs.dmlog")
+	WithStrictHeaderCommitments(nil)(cr)
+
+	var withTransactions *pbeth.Block
+	for {
+		block, err := cr.ReadBlock()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		ethBlock := &pbeth.Block{}
+		require.NoError(t, block.Payload.UnmarshalTo(ethBlock))
+		if withTransactions == nil && len(ethBlock.TransactionTraces) > 1 && len(ethBlock.TransactionTraces[0].Receipt.Logs) > 0 {
+			withTransactions = ethBlock
+		}
+	}
+	require.NotNil(t, withTransactions)
+
+	mismatches, err := VerifyHeaderCommitments(withTransactions, big.NewInt(1515), "")
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	// Changing a log changes the receipts root and the logs bloom, but not the transactions root
+	trace := withTransactions.TransactionTraces[0]
+	require.NotEmpty(t, trace.Receipt.Logs)
+	trace.Receipt.Logs[0].Address = []byte{0x01}
+
+	mismatches, err = VerifyHeaderCommitments(withTransactions, nil, "")
+	require.NoError(t, err)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "logs_bloom", mismatches[0].Field)
+	assert.Equal(t, "receipt_root", mismatches[1].Field)
+
+	trace.Nonce++
+
+	mismatches, err = VerifyHeaderCommitments(withTransactions, nil, "")
+	require.NoError(t, err)
+	require.Len(t, mismatches, 3)
+	assert.Equal(t, "transactions_root", mismatches[1].Field)
+}
+
+func TestConsensusTransactionTraces(t *testing.T) {
+	block := &pbeth.Block{Number: 10, Hash: B("aa")}
+	stateSync := &pbeth.TransactionTrace{Hash: computePolygonHash(block.Number, block.Hash)}
+	transfer := &pbeth.TransactionTrace{Hash: B("bb")}
+	block.TransactionTraces = []*pbeth.TransactionTrace{transfer, stateSync}
+
+	assert.Equal(t, []*pbeth.TransactionTrace{transfer}, consensusTransactionTraces(block, "polygon"))
+
+	// Only a Polygon node adds a state sync transaction, a transaction with the same hash on another
+	// chain is part of the consensus
+	assert.Equal(t, []*pbeth.TransactionTrace{transfer, stateSync}, consensusTransactionTraces(block, "geth"))
+	assert.Equal(t, []*pbeth.TransactionTrace{transfer, stateSync}, consensusTransactionTraces(block, ""))
+}
+
+func TestConsoleReader_StrictHeaderCommitments(t *testing.T) {
+	lines := testProtocol3Lines(t, 40)
+
+	for _, workers := range []int{1, 4} {
+		channel := make(chan string, len(lines))
+		for _, line := range lines {
+			channel <- line
+		}
+		close(channel)
+
+		cr := testReaderConsoleReader(t.Helper, channel, func() {})
+		cr.blockDecodeWorkers = workers
+		WithStrictHeaderCommitments(nil)(cr)
+
+		count := 0
+		for {
+			_, err := cr.ReadBlock()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 40, count)
+	}
+}
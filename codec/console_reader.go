@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/streamingfast/bstream"
 	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
 	"github.com/streamingfast/dmetrics"
+	"github.com/streamingfast/dstore"
 	firecore "github.com/streamingfast/firehose-core"
 	"github.com/streamingfast/firehose-core/node-manager/mindreader"
 	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
@@ -45,6 +47,11 @@ type ConsoleReader struct {
 	lines chan string
 	close func()
 
+	// pipeline decodes `FIRE BLOCK` lines concurrently, it is started once the `FIRE INIT` line
+	// reports Firehose protocol 3 if more than one block decode worker is configured
+	pipeline           *blockLinePipeline
+	blockDecodeWorkers int
+
 	ctx   *parseCtx
 	done  chan interface{}
 	stats *consoleReaderStats
@@ -52,7 +59,19 @@ type ConsoleReader struct {
 	logger *zap.Logger
 }
 
-func NewConsoleReader(lines chan string, blockEncoder firecore.BlockEncoder, logger *zap.Logger, tracer logging.Tracer) (mindreader.ConsolerReader, error) {
+// ConsoleReaderOption configures optional behaviors of the ConsoleReader
+type ConsoleReaderOption func(*ConsoleReader)
+
+// WithBlockDecodeWorkers sets the number of `FIRE BLOCK` lines (Firehose protocol 3) decoded
+// concurrently, blocks are still returned in the order they were received. A value of 1 or
+// less decodes the lines serially as they are read.
+func WithBlockDecodeWorkers(workers int) ConsoleReaderOption {
+	return func(c *ConsoleReader) {
+		c.blockDecodeWorkers = workers
+	}
+}
+
+func NewConsoleReader(lines chan string, blockEncoder firecore.BlockEncoder, logger *zap.Logger, tracer logging.Tracer, opts ...ConsoleReaderOption) (mindreader.ConsolerReader, error) {
 	globalStats := newConsoleReaderStats()
 	globalStats.StartPeriodicLogToZap(context.Background(), logger, 30*time.Second)
 
@@ -67,6 +86,14 @@ func NewConsoleReader(lines chan string, blockEncoder firecore.BlockEncoder, log
 		logger: logger,
 	}
 
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.ctx.payloadValidation == PayloadValidationQuarantine && l.ctx.quarantineStore == nil {
+		return nil, fmt.Errorf("payload validation mode %q requires a quarantine store", PayloadValidationQuarantine)
+	}
+
 	return l, nil
 }
 
@@ -77,6 +104,9 @@ func (l *ConsoleReader) Done() <-chan interface{} {
 
 func (c *ConsoleReader) Close() {
 	c.stats.StopPeriodicLogToZap()
+	if c.pipeline != nil {
+		c.pipeline.close()
+	}
 	c.close()
 }
 
@@ -188,6 +218,13 @@ type parseCtx struct {
 	normalizationFeatures            *normalizationFeatures
 	highestOrdinalBeforeTransactions int64
 
+	payloadValidation PayloadValidationMode
+	quarantineStore   dstore.Store
+
+	strictHeaderCommitments bool
+	chainID                 *big.Int
+	nodeVariant             string
+
 	transactionTraces   []*pbeth.TransactionTrace
 	systemCalls         []*pbeth.Call
 	evmCallStackIndexes []int32
@@ -231,7 +268,12 @@ func (c *ConsoleReader) next(readType int) (out interface{}, err error) {
 
 	c.logger.Debug("next", zap.Int("read_type", readType))
 
-	for line := range c.lines {
+	for {
+		line, pipelined, ok := c.nextLine()
+		if !ok {
+			break
+		}
+
 		switch {
 		case strings.HasPrefix(line, "DMLOG "):
 			line = line[6:]
@@ -260,6 +302,9 @@ func (c *ConsoleReader) next(readType int) (out interface{}, err error) {
 				return nil, fmt.Errorf("got 'FIRE BLOCK ...' line while Firehose protocol major version reported by 'FIRE INIT ...' was actually %d, this is invalid as 'FIRE BLOCK ...' can be emitted only if Firehose protocol major version is 3", ctx.fhMajorVersion)
 			}
 
+			if pipelined != nil {
+				return ctx.readPipelinedBlock(pipelined)
+			}
 			return ctx.readBlockForProtocolVersion3(line)
 
 		case strings.HasPrefix(line, "GAS_CHANGE"):
@@ -395,6 +440,11 @@ func (c *ConsoleReader) next(readType int) (out interface{}, err error) {
 				return nil, err
 			}
 
+			if ctx.fhMajorVersion == 3 && c.blockDecodeWorkers > 1 && c.pipeline == nil {
+				c.logger.Info("decoding block lines concurrently", zap.Int("workers", c.blockDecodeWorkers))
+				c.pipeline = newBlockLinePipeline(c.lines, c.blockDecodeWorkers, 4*c.blockDecodeWorkers, ctx.decodeBlockLine, c.logger)
+			}
+
 		default:
 			return nil, fmt.Errorf("unsupported log line: %q", line)
 		}
@@ -409,6 +459,25 @@ func (c *ConsoleReader) next(readType int) (out interface{}, err error) {
 	return nil, io.EOF
 }
 
+// nextLine returns the next line read from the instrumented node, along with its pipelined
+// version when the block line pipeline is active
+func (c *ConsoleReader) nextLine() (line string, pipelined *pipelinedLine, ok bool) {
+	if c.pipeline == nil {
+		line, ok = <-c.lines
+		return line, nil, ok
+	}
+
+	pipelined, ok = c.pipeline.next()
+	if !ok {
+		return "", nil, false
+	}
+
+	if pipelined.done == nil {
+		return pipelined.line, nil, true
+	}
+	return pipelined.line, pipelined, true
+}
+
 func (c *ConsoleReader) ProcessData(reader io.Reader) error {
 	scanner := c.buildScanner(reader)
 	for scanner.Scan() {
@@ -1167,9 +1236,8 @@ func (ctx *parseCtx) readInit(line string) error {
 	// So, we print transaction rate only if current tracer major version is 2
 	ctx.globalStats.printTransactionRate = ctx.fhMajorVersion == 2
 
-	if nodeVariant == "polygon" {
-		ctx.normalizationFeatures.CombinePolygonSystemTransactions = true
-	}
+	ctx.nodeVariant = nodeVariant
+	ctx.normalizationFeatures.NodeVariantNormalizers = nodeVariantNormalizers(nodeVariant)
 
 	ctx.logger.Info("read firehose instrumentation init line",
 		zap.String("fh_version", ctx.fhVersion),
@@ -1263,6 +1331,80 @@ func (ctx *parseCtx) readCodeChange(line string) error {
 func (ctx *parseCtx) readBlockForProtocolVersion3(line string) (*pbbstream.Block, error) {
 	start := time.Now()
 
+	block, mismatches, err := ctx.decodeBlockLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	BlockTotalParseTime.AddInt64(int64(time.Since(start)))
+
+	return ctx.blockDecoded(line, block, mismatches)
+}
+
+// readPipelinedBlock returns the block of a `FIRE BLOCK` line decoded by the block line pipeline,
+// waiting for its decoding to complete
+func (ctx *parseCtx) readPipelinedBlock(pipelined *pipelinedLine) (*pbbstream.Block, error) {
+	block, err := pipelined.wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.blockDecoded(pipelined.line[len("FIRE "):], block, pipelined.mismatches)
+}
+
+// decodeBlockLine decodes a `FIRE BLOCK` line and runs the configured checks on its payload, it
+// only reads the reader's configuration so that lines can be decoded concurrently
+func (ctx *parseCtx) decodeBlockLine(line string) (block *pbbstream.Block, mismatches []payloadMismatch, err error) {
+	block, err = parseBlockLine(line)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ctx.validatesPayload() {
+		mismatches = validateBlockPayload(block)
+	}
+
+	if ctx.strictHeaderCommitments {
+		ethBlock := &pbeth.Block{}
+		if err := block.Payload.UnmarshalTo(ethBlock); err != nil {
+			return nil, nil, fmt.Errorf("decoding block #%d payload: %w", block.Number, err)
+		}
+
+		if err := ctx.checkHeaderCommitments(ethBlock); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return block, mismatches, nil
+}
+
+func (ctx *parseCtx) blockDecoded(line string, block *pbbstream.Block, mismatches []payloadMismatch) (*pbbstream.Block, error) {
+	if ctx.validatesPayload() {
+		if err := ctx.checkBlockPayload(line, block, mismatches); err != nil {
+			return nil, err
+		}
+	}
+	ctx.blockRead(block)
+
+	return block, nil
+}
+
+func (ctx *parseCtx) validatesPayload() bool {
+	return ctx.payloadValidation != "" && ctx.payloadValidation != PayloadValidationOff
+}
+
+func (ctx *parseCtx) blockRead(block *pbbstream.Block) {
+	BlockReadCount.Inc()
+
+	ctx.globalStats.lastBlock = pbbstream.BlockRef{
+		Num: block.Number,
+		Id:  block.Id,
+	}
+}
+
+// parseBlockLine decodes a `FIRE BLOCK` line, without its `FIRE ` prefix, into a block whose
+// payload is left encoded
+func parseBlockLine(line string) (*pbbstream.Block, error) {
 	chunks, err := SplitInBoundedChunks(line, 8)
 	if err != nil {
 		return nil, fmt.Errorf("splitting block log line: %w", err)
@@ -1314,14 +1456,6 @@ func (ctx *parseCtx) readBlockForProtocolVersion3(line string) (*pbbstream.Block
 		Payload:   blockPayload,
 	}
 
-	BlockReadCount.Inc()
-	BlockTotalParseTime.AddInt64(int64(time.Since(start)))
-
-	ctx.globalStats.lastBlock = pbbstream.BlockRef{
-		Num: blockNum,
-		Id:  blockHash,
-	}
-
 	return block, nil
 }
 
@@ -1390,6 +1524,12 @@ func (ctx *parseCtx) readEndBlock(line string) (*pbbstream.Block, error) {
 
 	normalizeInPlace(block, ctx.normalizationFeatures, uint64(ctx.highestOrdinalBeforeTransactions+1))
 
+	if ctx.strictHeaderCommitments {
+		if err := ctx.checkHeaderCommitments(block); err != nil {
+			return nil, err
+		}
+	}
+
 	var libNum uint64
 	if len(endBlockData.FinalizedBlockHash) > 0 {
 		libNum = computeProofOfStakeLIBNum(blockNum, uint64(endBlockData.FinalizedBlockNum), bstream.GetProtocolFirstStreamableBlock)
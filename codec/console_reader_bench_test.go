@@ -16,10 +16,13 @@ package codec
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"os"
 	"testing"
+
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 )
 
 func BenchmarkConsoleReader(b *testing.B) {
@@ -75,3 +78,86 @@ func BenchmarkConsoleReader(b *testing.B) {
 
 	b.ReportMetric(float64(expectedBlockCount*b.N), "blocks/op")
 }
+
+func BenchmarkConsoleReader_Protocol3(b *testing.B) {
+	blockCount := 500
+	lines := testProtocol3Lines(b, blockCount)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			readers := make([]*ConsoleReader, b.N)
+			for n := 0; n < b.N; n++ {
+				channel := make(chan string, len(lines))
+				for _, line := range lines {
+					channel <- line
+				}
+				close(channel)
+
+				readers[n] = testReaderConsoleReader(b.Helper, channel, func() {})
+				readers[n].blockDecodeWorkers = workers
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for n := 0; n < b.N; n++ {
+				reader := readers[n]
+
+				count := 0
+				for {
+					_, err := reader.ReadBlock()
+					if err == io.EOF {
+						break
+					}
+
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					count++
+				}
+
+				if count != blockCount {
+					b.Fatal(fmt.Errorf("expected to have read %d blocks but got %d", blockCount, count))
+				}
+			}
+
+			b.ReportMetric(float64(blockCount*b.N)/b.Elapsed().Seconds(), "blocks/s")
+		})
+	}
+}
+
+// testProtocol3Lines returns the `FIRE INIT` line of Firehose protocol 3 followed by `blockCount`
+// `FIRE BLOCK` lines, cycling through the blocks of the protocol 2 test file
+func testProtocol3Lines(tb testing.TB, blockCount int) []string {
+	tb.Helper()
+
+	reader := testFileConsoleReader(tb, "testdata/firehose-logs.dmlog")
+
+	var blockLines []string
+	for {
+		block, err := reader.ReadBlock()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		ethBlock := &pbeth.Block{}
+		if err := block.Payload.UnmarshalTo(ethBlock); err != nil {
+			tb.Fatal(err)
+		}
+
+		blockLines = append(blockLines, fmt.Sprintf("FIRE BLOCK %d %s %d %s %d %d %s",
+			ethBlock.Number, ethBlock.ID(), ethBlock.Number-1, ethBlock.PreviousID(), block.LibNum, ethBlock.MustTime().UnixNano(),
+			base64.StdEncoding.EncodeToString(block.Payload.Value),
+		))
+	}
+
+	lines := []string{"FIRE INIT 3.0 geth 1.14.0-fh3.0"}
+	for i := 0; i < blockCount; i++ {
+		lines = append(lines, blockLines[i%len(blockLines)])
+	}
+	return lines
+}
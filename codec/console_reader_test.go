@@ -30,6 +30,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
 	firecore "github.com/streamingfast/firehose-core"
 	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 	"github.com/streamingfast/jsonpb"
@@ -184,7 +185,7 @@ func tempDir() string {
 	return os.TempDir()
 }
 
-func testFileConsoleReader(t *testing.T, filename string) *ConsoleReader {
+func testFileConsoleReader(t testing.TB, filename string) *ConsoleReader {
 	t.Helper()
 
 	fl, err := os.Open(filename)
@@ -293,3 +294,55 @@ func Test_computeProofOfStakeLIBNum(t *testing.T) {
 		})
 	}
 }
+
+func TestConsoleReader_PipelinedBlockLines(t *testing.T) {
+	lines := testProtocol3Lines(t, 50)
+	// Non-block lines are still handled in order with the blocks
+	lines = append(lines[:20:20], append([]string{"some node log", "FIRE TRX_ENTER_POOL 0x00"}, lines[20:]...)...)
+
+	readAll := func(workers int, lines []string) (blocks []*pbbstream.Block, err error) {
+		channel := make(chan string, len(lines))
+		for _, line := range lines {
+			channel <- line
+		}
+		close(channel)
+
+		cr := testReaderConsoleReader(t.Helper, channel, func() {})
+		cr.blockDecodeWorkers = workers
+		defer func() {
+			if cr.pipeline != nil {
+				cr.pipeline.close()
+			}
+		}()
+
+		for {
+			block, err := cr.ReadBlock()
+			if err == io.EOF {
+				return blocks, nil
+			}
+			if err != nil {
+				return blocks, err
+			}
+			blocks = append(blocks, block)
+		}
+	}
+
+	expected, err := readAll(1, lines)
+	require.NoError(t, err)
+	require.Len(t, expected, 50)
+
+	actual, err := readAll(4, lines)
+	require.NoError(t, err)
+	require.Len(t, actual, 50)
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i], actual[i]), "block #%d", expected[i].Number)
+	}
+
+	// A decoding error is returned in order, after the blocks preceding the invalid line
+	invalid := append([]string{}, lines...)
+	invalid[30] = "FIRE BLOCK 29 invalid"
+
+	actual, err = readAll(4, invalid)
+	require.Error(t, err)
+	assert.Len(t, actual, 27)
+}
@@ -15,3 +15,8 @@ var TransactionReadCount = metrics.NewCounter("trx_read_count", "The number of t
 
 var BlockTotalParseTime = metrics.NewCounter("block_total_parse_time", "The total parse time (wall clock) it took to extract all blocks so far")
 var TrxTotalParseTime = metrics.NewCounter("trx_total_parse_time", "The total parse time (wall clock) it took to extract all transactions so far")
+
+var BlockPayloadValidatedCount = metrics.NewCounter("block_payload_validated_count", "The number of protocol 3 block payloads validated against their line by the Console Reader")
+var BlockPayloadMismatchCount = metrics.NewCounterVec("block_payload_mismatch_count", []string{"field"}, "The number of protocol 3 block payloads disagreeing with their line, by field")
+var BlockPayloadQuarantinedCount = metrics.NewCounter("block_payload_quarantined_count", "The number of protocol 3 block lines written to the quarantine store because of a payload mismatch")
+var HeaderCommitmentMismatchCount = metrics.NewCounterVec("header_commitment_mismatch_count", []string{"field"}, "The number of blocks whose header commitments (logs bloom, transactions root, receipts root) disagree with their content, by field")
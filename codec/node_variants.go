@@ -0,0 +1,178 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+// BlockNormalizer is a normalization specific to a node variant, run in place on each block
+// assembled by the reader (Firehose protocol 2.x) by `normalizeInPlace`, after the calls' state
+// reverted values are populated and before the transactions are reordered and their status
+// populated.
+//
+// Normalize returns the hashes of the block's system transactions, if any, whose receipt logs are
+// numbered as is and after the logs of the other transactions.
+type BlockNormalizer struct {
+	Name      string                                                      `json:"name"`
+	Normalize func(block *pbeth.Block) (systemTransactionHashes [][]byte) `json:"-"`
+}
+
+var nodeVariantsLock sync.RWMutex
+var nodeVariants = map[string][]BlockNormalizer{}
+
+// RegisterNodeVariant registers the normalizers of the node variant announced as `variant` by the
+// `FIRE INIT` line (case insensitive), they are run in order on each block read from such a node.
+// Registering the same variant twice panics.
+func RegisterNodeVariant(variant string, normalizers ...BlockNormalizer) {
+	variant = strings.ToLower(variant)
+
+	nodeVariantsLock.Lock()
+	defer nodeVariantsLock.Unlock()
+
+	if _, found := nodeVariants[variant]; found {
+		panic(fmt.Errorf("node variant %q is already registered", variant))
+	}
+	nodeVariants[variant] = normalizers
+}
+
+// nodeVariantNormalizers returns the normalizers of the node variant, nil for a variant that was
+// not registered (e.g. `geth`), whose blocks need no specific normalization
+func nodeVariantNormalizers(variant string) []BlockNormalizer {
+	nodeVariantsLock.RLock()
+	defer nodeVariantsLock.RUnlock()
+
+	return nodeVariants[strings.ToLower(variant)]
+}
+
+func init() {
+	RegisterNodeVariant("polygon", BlockNormalizer{Name: "combine_polygon_system_transactions", Normalize: combinePolygonSystemTransactions})
+	RegisterNodeVariant("bsc", BlockNormalizer{Name: "order_bsc_system_transactions", Normalize: orderBSCSystemTransactions})
+	RegisterNodeVariant("nitro",
+		BlockNormalizer{Name: "clear_arbitrum_unsigned_signatures", Normalize: clearArbitrumUnsignedSignatures},
+		BlockNormalizer{Name: "succeed_arbitrum_internal_transactions", Normalize: succeedArbitrumInternalTransactions},
+	)
+}
+
+func combinePolygonSystemTransactions(block *pbeth.Block) (systemTransactionHashes [][]byte) {
+	block.TransactionTraces, systemTransactionHashes = CombinePolygonSystemTransactions(block.TransactionTraces, block.Number, block.Hash)
+	return
+}
+
+// bscSystemContracts are the genesis system contracts of BNB Smart Chain, the validator calls
+// them through system transactions appended at the end of each block by the Parlia engine
+var bscSystemContracts = []eth.Address{
+	eth.MustNewAddress("0x0000000000000000000000000000000000001000"), // ValidatorContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001001"), // SlashContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001002"), // SystemRewardContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001003"), // LightClientContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001004"), // TokenHubContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001005"), // RelayerIncentivizeContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001006"), // RelayerHubContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001007"), // GovHubContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000001008"), // TokenManagerContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002000"), // CrossChainContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002001"), // StakingContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002002"), // StakeHubContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002003"), // StakeCreditContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002004"), // GovernorContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002005"), // GovTokenContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000002006"), // TimelockContract
+	eth.MustNewAddress("0x0000000000000000000000000000000000003000"), // TokenRecoverPortalContract
+}
+
+// isBSCSystemTransaction follows `Parlia.IsSystemTransaction`: a transaction without gas price
+// sent by the block's validator to a system contract
+func isBSCSystemTransaction(trace *pbeth.TransactionTrace, coinbase []byte) bool {
+	if !bytes.Equal(trace.From, coinbase) || trace.GasPrice.Native().Sign() != 0 {
+		return false
+	}
+
+	for _, contract := range bscSystemContracts {
+		if bytes.Equal(trace.To, contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderBSCSystemTransactions moves the system transactions of a BSC block after the other ones,
+// where the Parlia engine executes them when finalizing the block, and numbers their index
+// accordingly
+func orderBSCSystemTransactions(block *pbeth.Block) (systemTransactionHashes [][]byte) {
+	coinbase := block.GetHeader().GetCoinbase()
+	if len(coinbase) == 0 {
+		return nil
+	}
+
+	var systemTransactions []*pbeth.TransactionTrace
+	normalTransactions := make([]*pbeth.TransactionTrace, 0, len(block.TransactionTraces))
+	for _, trace := range block.TransactionTraces {
+		if isBSCSystemTransaction(trace, coinbase) {
+			systemTransactions = append(systemTransactions, trace)
+			continue
+		}
+		normalTransactions = append(normalTransactions, trace)
+	}
+
+	if len(systemTransactions) == 0 {
+		return nil
+	}
+
+	nextIndex := uint32(0)
+	for _, trace := range normalTransactions {
+		if trace.Index >= nextIndex {
+			nextIndex = trace.Index + 1
+		}
+	}
+
+	for _, trace := range systemTransactions {
+		trace.Index = nextIndex
+		nextIndex++
+		systemTransactionHashes = append(systemTransactionHashes, trace.Hash)
+	}
+
+	block.TransactionTraces = append(normalTransactions, systemTransactions...)
+	return systemTransactionHashes
+}
+
+func isArbitrumUnsignedTransaction(trace *pbeth.TransactionTrace) bool {
+	switch trace.Type {
+	case pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_DEPOSIT,
+		pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_UNSIGNED,
+		pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_CONTRACT,
+		pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_RETRY,
+		pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_SUBMIT_RETRYABLE,
+		pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_INTERNAL:
+		return true
+	}
+	return false
+}
+
+// clearArbitrumUnsignedSignatures removes the signature of the Arbitrum transactions that are not
+// signed (deposits, retryables, internal transactions, ...), which Nitro reports as zero values
+// that would otherwise be padded to 32 zero bytes like real signature points
+func clearArbitrumUnsignedSignatures(block *pbeth.Block) [][]byte {
+	for _, trace := range block.TransactionTraces {
+		if isArbitrumUnsignedTransaction(trace) {
+			trace.V, trace.R, trace.S = nil, nil, nil
+		}
+	}
+	return nil
+}
+
+// succeedArbitrumInternalTransactions marks as succeeded the ArbOS internal transactions executed
+// without any EVM call, whose status cannot be derived from their root call. Nitro halts on a
+// failed internal transaction, so a block never contains one.
+func succeedArbitrumInternalTransactions(block *pbeth.Block) [][]byte {
+	for _, trace := range block.TransactionTraces {
+		if trace.Type == pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_INTERNAL && trace.Status == pbeth.TransactionTraceStatus_UNKNOWN && len(trace.Calls) == 0 {
+			trace.Status = pbeth.TransactionTraceStatus_SUCCEEDED
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package codec
+
+import (
+	"testing"
+
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadInit_NodeVariantNormalizers(t *testing.T) {
+	normalizerNames := func(initLine string) []string {
+		ctx := &parseCtx{logger: zlog, globalStats: newConsoleReaderStats(), normalizationFeatures: &normalizationFeatures{}}
+		require.NoError(t, ctx.readInit(initLine))
+
+		var names []string
+		for _, normalizer := range ctx.normalizationFeatures.NodeVariantNormalizers {
+			names = append(names, normalizer.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"combine_polygon_system_transactions"}, normalizerNames("INIT 2.5 polygon 1.2.3-fh2.5"))
+	assert.Equal(t, []string{"order_bsc_system_transactions"}, normalizerNames("INIT 2.5 BSC 1.4.5-fh2.5"))
+	assert.Equal(t, []string{"clear_arbitrum_unsigned_signatures", "succeed_arbitrum_internal_transactions"}, normalizerNames("INIT 2.5 nitro 3.0.0-fh2.5"))
+	assert.Nil(t, normalizerNames("INIT 2.5 geth 1.13.0-fh2.5"))
+	assert.Nil(t, normalizerNames("INIT 2.5 1.13.0-fh2.5"))
+}
+
+func TestRegisterNodeVariant_Duplicate(t *testing.T) {
+	assert.Panics(t, func() { RegisterNodeVariant("Polygon") })
+}
+
+func TestOrderBSCSystemTransactions(t *testing.T) {
+	validator := B("00000000000000000000000000000000000000aa")
+	validatorContract := B("0000000000000000000000000000000000001000")
+	slashContract := B("0000000000000000000000000000000000001001")
+
+	trx := func(hash string, index uint32, from, to []byte, gasPrice int64) *pbeth.TransactionTrace {
+		return &pbeth.TransactionTrace{Hash: B(hash), Index: index, From: from, To: to, GasPrice: pbeth.NewBigInt(gasPrice)}
+	}
+
+	block := &pbeth.Block{
+		Header: &pbeth.BlockHeader{Coinbase: validator},
+		TransactionTraces: []*pbeth.TransactionTrace{
+			trx("01", 0, validator, slashContract, 0),
+			trx("02", 1, B("00000000000000000000000000000000000000bb"), validatorContract, 0),
+			// A transaction of the validator to a system contract paying gas is a normal one
+			trx("03", 2, validator, validatorContract, 1),
+			trx("04", 3, validator, validatorContract, 0),
+		},
+	}
+
+	systemTransactionHashes := orderBSCSystemTransactions(block)
+	assert.Equal(t, [][]byte{B("01"), B("04")}, systemTransactionHashes)
+
+	var hashes []string
+	var indexes []uint32
+	for _, trace := range block.TransactionTraces {
+		hashes = append(hashes, H(trace.Hash))
+		indexes = append(indexes, trace.Index)
+	}
+	assert.Equal(t, []string{"02", "03", "01", "04"}, hashes)
+	assert.Equal(t, []uint32{1, 2, 3, 4}, indexes)
+
+	assert.Nil(t, orderBSCSystemTransactions(&pbeth.Block{Header: &pbeth.BlockHeader{Coinbase: validator}, TransactionTraces: []*pbeth.TransactionTrace{
+		trx("05", 0, validator, B("00000000000000000000000000000000000000cc"), 0),
+	}}))
+}
+
+func TestNitroNormalizers(t *testing.T) {
+	zero := []byte{0x00}
+	block := &pbeth.Block{
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{Type: pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_INTERNAL, V: zero, R: zero, S: zero},
+			{Type: pbeth.TransactionTrace_TRX_TYPE_ARBITRUM_DEPOSIT, V: zero, R: zero, S: zero, Calls: []*pbeth.Call{{Index: 1}}},
+			{Type: pbeth.TransactionTrace_TRX_TYPE_DYNAMIC_FEE, V: []byte{0x01}, R: []byte{0x02}, S: []byte{0x03}},
+		},
+	}
+
+	features := &normalizationFeatures{NodeVariantNormalizers: nodeVariantNormalizers("nitro")}
+	for _, normalizer := range features.NodeVariantNormalizers {
+		assert.Nil(t, normalizer.Normalize(block))
+	}
+
+	internal, deposit, signed := block.TransactionTraces[0], block.TransactionTraces[1], block.TransactionTraces[2]
+
+	assert.Nil(t, internal.R)
+	assert.Equal(t, pbeth.TransactionTraceStatus_SUCCEEDED, internal.Status)
+
+	// Status of transactions with calls is derived from their root call by normalizeInPlace
+	assert.Nil(t, deposit.S)
+	assert.Equal(t, pbeth.TransactionTraceStatus_UNKNOWN, deposit.Status)
+
+	assert.Equal(t, []byte{0x01}, signed.V)
+	assert.Equal(t, []byte{0x02}, signed.R)
+	assert.Equal(t, []byte{0x03}, signed.S)
+}
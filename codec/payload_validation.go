@@ -0,0 +1,148 @@
+package codec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"go.uber.org/zap"
+)
+
+// PayloadValidationMode controls what the console reader does of a `FIRE BLOCK` line (Firehose
+// protocol 3) whose fields disagree with the `sf.ethereum.type.v2.Block` payload it carries
+type PayloadValidationMode string
+
+const (
+	// PayloadValidationOff trusts the line's fields without decoding the payload
+	PayloadValidationOff PayloadValidationMode = "off"
+
+	// PayloadValidationFail makes the console reader return an error on the first mismatch
+	PayloadValidationFail PayloadValidationMode = "fail"
+
+	// PayloadValidationQuarantine writes the mismatching lines to the quarantine store and keeps
+	// emitting the blocks, so that the mismatch can be investigated without stopping the reader
+	PayloadValidationQuarantine PayloadValidationMode = "quarantine"
+)
+
+func ParsePayloadValidationMode(in string) (PayloadValidationMode, error) {
+	switch mode := PayloadValidationMode(strings.ToLower(in)); mode {
+	case PayloadValidationOff, PayloadValidationFail, PayloadValidationQuarantine:
+		return mode, nil
+	case "":
+		return PayloadValidationOff, nil
+	default:
+		return "", fmt.Errorf("invalid payload validation mode %q, expected one of [off, fail, quarantine]", in)
+	}
+}
+
+// WithPayloadValidation decodes the payload of each `FIRE BLOCK` line and checks it agrees with
+// the line's fields, quarantineStore receiving the mismatching lines in PayloadValidationQuarantine
+// mode
+func WithPayloadValidation(mode PayloadValidationMode, quarantineStore dstore.Store) ConsoleReaderOption {
+	return func(c *ConsoleReader) {
+		c.ctx.payloadValidation = mode
+		c.ctx.quarantineStore = quarantineStore
+	}
+}
+
+type payloadMismatch struct {
+	field    string
+	line     string
+	payload  string
+	contents string
+}
+
+func (m payloadMismatch) String() string {
+	if m.contents != "" {
+		return fmt.Sprintf("%s (%s)", m.field, m.contents)
+	}
+	return fmt.Sprintf("%s (line %s, payload %s)", m.field, m.line, m.payload)
+}
+
+// validateBlockPayload decodes the block's payload and returns the fields on which it disagrees
+// with the block built from the `FIRE BLOCK` line, it has no side effect so that blocks can be
+// validated concurrently
+func validateBlockPayload(block *pbbstream.Block) (mismatches []payloadMismatch) {
+	ethBlock := &pbeth.Block{}
+	if err := block.Payload.UnmarshalTo(ethBlock); err != nil {
+		return []payloadMismatch{{field: "payload", contents: fmt.Sprintf("cannot decode: %s", err)}}
+	}
+
+	if ethBlock.Number != block.Number {
+		mismatches = append(mismatches, payloadMismatch{field: "number", line: fmt.Sprintf("%d", block.Number), payload: fmt.Sprintf("%d", ethBlock.Number)})
+	}
+
+	if ethBlock.ID() != normalizeHash(block.Id) {
+		mismatches = append(mismatches, payloadMismatch{field: "hash", line: block.Id, payload: ethBlock.ID()})
+	}
+
+	if ethBlock.Header == nil {
+		mismatches = append(mismatches, payloadMismatch{field: "header", contents: "missing from payload"})
+	} else {
+		if ethBlock.PreviousID() != normalizeHash(block.ParentId) {
+			mismatches = append(mismatches, payloadMismatch{field: "parent_hash", line: block.ParentId, payload: ethBlock.PreviousID()})
+		}
+
+		if !ethBlock.Header.Timestamp.AsTime().Equal(block.Timestamp.AsTime()) {
+			mismatches = append(mismatches, payloadMismatch{field: "timestamp", line: block.Timestamp.AsTime().Format(time.RFC3339Nano), payload: ethBlock.Header.Timestamp.AsTime().Format(time.RFC3339Nano)})
+		}
+	}
+
+	if ethBlock.Ver != 3 {
+		mismatches = append(mismatches, payloadMismatch{field: "ver", contents: fmt.Sprintf("expected 3, payload has %d", ethBlock.Ver)})
+	}
+
+	switch ethBlock.DetailLevel {
+	case pbeth.Block_DETAILLEVEL_BASE, pbeth.Block_DETAILLEVEL_EXTENDED:
+	default:
+		mismatches = append(mismatches, payloadMismatch{field: "detail_level", contents: fmt.Sprintf("unknown detail level %d", ethBlock.DetailLevel)})
+	}
+
+	return mismatches
+}
+
+func normalizeHash(in string) string {
+	return strings.TrimPrefix(strings.ToLower(in), "0x")
+}
+
+// checkBlockPayload records the outcome of the payload validation of a block and applies the
+// configured payload validation mode, line being the `FIRE BLOCK` line without its `FIRE ` prefix
+func (ctx *parseCtx) checkBlockPayload(line string, block *pbbstream.Block, mismatches []payloadMismatch) error {
+	BlockPayloadValidatedCount.Inc()
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	var descriptions []string
+	for _, mismatch := range mismatches {
+		BlockPayloadMismatchCount.Inc(mismatch.field)
+		descriptions = append(descriptions, mismatch.String())
+	}
+
+	if ctx.payloadValidation == PayloadValidationFail {
+		return fmt.Errorf("block #%d (%s) payload is inconsistent with its 'FIRE BLOCK' line: %s", block.Number, block.Id, strings.Join(descriptions, ", "))
+	}
+
+	filename := fmt.Sprintf("%010d-%s.line", block.Number, normalizeHash(block.Id))
+	ctx.logger.Warn("block payload is inconsistent with its 'FIRE BLOCK' line, quarantining the line",
+		zap.Uint64("block_num", block.Number),
+		zap.String("block_hash", block.Id),
+		zap.Strings("mismatches", descriptions),
+		zap.String("filename", filename),
+	)
+
+	writeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := ctx.quarantineStore.WriteObject(writeCtx, filename, bytes.NewReader([]byte("FIRE "+line))); err != nil {
+		return fmt.Errorf("quarantining block #%d line: %w", block.Number, err)
+	}
+	BlockPayloadQuarantinedCount.Inc()
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleReader_PayloadValidation(t *testing.T) {
+	lines := testProtocol3Lines(t, 10)
+
+	// Swaps the hash on the line of the 5th block with the one of the 6th block
+	tampered := append([]string{}, lines...)
+	chunks := strings.Split(tampered[5], " ")
+	chunks[3] = strings.Split(lines[6], " ")[3]
+	tampered[5] = strings.Join(chunks, " ")
+
+	readAll := func(t *testing.T, lines []string, workers int, mode PayloadValidationMode, quarantineStore dstore.Store) (blocks []*pbbstream.Block, err error) {
+		channel := make(chan string, len(lines))
+		for _, line := range lines {
+			channel <- line
+		}
+		close(channel)
+
+		cr := testReaderConsoleReader(t.Helper, channel, func() {})
+		cr.blockDecodeWorkers = workers
+		WithPayloadValidation(mode, quarantineStore)(cr)
+		defer func() {
+			if cr.pipeline != nil {
+				cr.pipeline.close()
+			}
+		}()
+
+		for {
+			block, err := cr.ReadBlock()
+			if err == io.EOF {
+				return blocks, nil
+			}
+			if err != nil {
+				return blocks, err
+			}
+			blocks = append(blocks, block)
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			blocks, err := readAll(t, lines, workers, PayloadValidationFail, nil)
+			require.NoError(t, err)
+			assert.Len(t, blocks, 10)
+
+			blocks, err = readAll(t, tampered, workers, PayloadValidationFail, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "payload is inconsistent with its 'FIRE BLOCK' line: hash (line ")
+			assert.Len(t, blocks, 4)
+
+			store := dstore.NewMockStore(nil)
+			blocks, err = readAll(t, tampered, workers, PayloadValidationQuarantine, store)
+			require.NoError(t, err)
+			assert.Len(t, blocks, 10)
+
+			require.Len(t, store.Files, 1)
+			for filename, content := range store.Files {
+				assert.Equal(t, fmt.Sprintf("%010d-%s.line", blocks[4].Number, blocks[4].Id), filename)
+				assert.Equal(t, tampered[5], string(content))
+			}
+		})
+	}
+}
+
+func TestParsePayloadValidationMode(t *testing.T) {
+	mode, err := ParsePayloadValidationMode("")
+	require.NoError(t, err)
+	assert.Equal(t, PayloadValidationOff, mode)
+
+	mode, err = ParsePayloadValidationMode("Quarantine")
+	require.NoError(t, err)
+	assert.Equal(t, PayloadValidationQuarantine, mode)
+
+	_, err = ParsePayloadValidationMode("drop")
+	assert.Error(t, err)
+}
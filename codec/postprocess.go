@@ -21,9 +21,10 @@ var nullAddress = eth.MustNewAddress("0x0000000000000000000000000000000000000000
 var bigIntZero = pbeth.BigIntFromBytes(nil)
 
 type normalizationFeatures struct {
-	CombinePolygonSystemTransactions       bool
 	ReorderTransactionsAndRenumberOrdinals bool
 	UpgradeBlockV2ToV3                     bool
+	// NodeVariantNormalizers are the normalizers registered for the node variant of the `FIRE INIT` line
+	NodeVariantNormalizers []BlockNormalizer
 }
 
 func normalizeInPlace(block *pbeth.Block, features *normalizationFeatures, firstTransactionOrdinal uint64) {
@@ -32,8 +33,8 @@ func normalizeInPlace(block *pbeth.Block, features *normalizationFeatures, first
 	}
 
 	var systemTransactionHashes hashes
-	if features.CombinePolygonSystemTransactions {
-		block.TransactionTraces, systemTransactionHashes = CombinePolygonSystemTransactions(block.TransactionTraces, block.Number, block.Hash)
+	for _, normalizer := range features.NodeVariantNormalizers {
+		systemTransactionHashes = append(systemTransactionHashes, normalizer.Normalize(block)...)
 	}
 
 	if features.ReorderTransactionsAndRenumberOrdinals {
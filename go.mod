@@ -36,6 +36,7 @@ require (
 	go.uber.org/zap v1.26.0
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.33.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require (
@@ -204,7 +205,6 @@ require (
 	k8s.io/utils v0.0.0-20220728103510-ee6ede2d64ed // indirect
 	sigs.k8s.io/json v0.0.0-20220713155537-f223a00ba0e2 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.2.3 // indirect
-	sigs.k8s.io/yaml v1.2.0 // indirect
 )
 
 replace (
@@ -213,6 +213,7 @@ replace (
 	github.com/gorilla/rpc => github.com/streamingfast/rpc v1.2.1-0.20201124195002-f9fc01524e38
 	github.com/graph-gophers/graphql-go => github.com/streamingfast/graphql-go v0.0.0-20210204202750-0e485a040a3c
 	github.com/jhump/protoreflect => github.com/streamingfast/protoreflect v0.0.0-20231205191344-4b629d20ce8d
+	github.com/streamingfast/firehose-ethereum/types => ./types
 )
 
 retract v1.4.5 //included private repositories
@@ -0,0 +1,249 @@
+package transform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	"go.uber.org/zap"
+)
+
+// addressSet provides constant time lookups on lists of addresses, which can hold
+// tens of thousands of entries when loaded from an address list URL
+type addressSet map[string]struct{}
+
+func newAddressSet(addresses []eth.Address) addressSet {
+	out := make(addressSet, len(addresses))
+	for _, addr := range addresses {
+		out[string(addr)] = struct{}{}
+	}
+	return out
+}
+
+func (s addressSet) contains(addr eth.Address) bool {
+	_, found := s[string(addr)]
+	return found
+}
+
+// withAddressList returns the addresses extended with the ones of the address list stored at
+// url, when not empty, duplicates being removed
+func withAddressList(addresses []eth.Address, url string) ([]eth.Address, error) {
+	if url == "" {
+		return addresses, nil
+	}
+
+	list, err := addressLists.load(url)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := newAddressSet(addresses)
+	out := append(make([]eth.Address, 0, len(addresses)+len(list)), addresses...)
+	for _, addr := range list {
+		if !seen.contains(addr) {
+			seen[string(addr)] = struct{}{}
+			out = append(out, addr)
+		}
+	}
+	return out, nil
+}
+
+const addressListCacheTTL = 10 * time.Minute
+const addressListCacheMaxEntries = 256
+const addressListLoadTimeout = 2 * time.Minute
+const addressListMaxSize = 64 * 1024 * 1024
+
+var addressLists = &addressListCache{entries: make(map[string]*addressListEntry)}
+
+// ConfigureAddressLists sets the store from which the address lists referenced by the filters'
+// `addresses_url` are read, filters referencing an address list are refused while it is nil.
+// Clients can only reference files under the store, by their name relative to it or by their
+// full URL.
+func ConfigureAddressLists(store dstore.Store) {
+	addressLists.lock.Lock()
+	defer addressLists.lock.Unlock()
+
+	addressLists.store = store
+	addressLists.entries = make(map[string]*addressListEntry)
+}
+
+// addressListCache keeps the address lists loaded from the store so that requests sharing the
+// same list do not load it again, a list is reloaded once older than addressListCacheTTL and at
+// most addressListCacheMaxEntries lists are kept
+type addressListCache struct {
+	lock    sync.Mutex
+	store   dstore.Store
+	entries map[string]*addressListEntry
+}
+
+type addressListEntry struct {
+	done      chan struct{}
+	addresses []eth.Address
+	err       error
+	loadedAt  time.Time
+}
+
+func (c *addressListCache) load(url string) ([]eth.Address, error) {
+	c.lock.Lock()
+	store := c.store
+	if store == nil {
+		c.lock.Unlock()
+		return nil, fmt.Errorf("address list %q: address lists are not enabled on this server", url)
+	}
+
+	name, err := addressListName(store, url)
+	if err != nil {
+		c.lock.Unlock()
+		return nil, fmt.Errorf("address list %q: %w", url, err)
+	}
+
+	entry := c.entries[name]
+	if entry == nil || entry.expired() {
+		c.evict()
+
+		entry = &addressListEntry{done: make(chan struct{})}
+		c.entries[name] = entry
+		c.lock.Unlock()
+
+		entry.addresses, entry.err = readAddressList(store, name)
+		entry.loadedAt = time.Now()
+		close(entry.done)
+
+		if entry.err != nil {
+			c.lock.Lock()
+			if c.entries[name] == entry {
+				delete(c.entries, name)
+			}
+			c.lock.Unlock()
+		}
+		return entry.addresses, entry.err
+	}
+	c.lock.Unlock()
+
+	<-entry.done
+	return entry.addresses, entry.err
+}
+
+// evict makes room for a new entry, removing the expired entries then, if the cache is still
+// full, the oldest loaded ones, it must be called with the lock held
+func (c *addressListCache) evict() {
+	for name, entry := range c.entries {
+		if entry.expired() {
+			delete(c.entries, name)
+		}
+	}
+
+	for len(c.entries) >= addressListCacheMaxEntries {
+		var oldestName string
+		var oldest *addressListEntry
+		for name, entry := range c.entries {
+			if !entry.loaded() {
+				continue
+			}
+			if oldest == nil || entry.loadedAt.Before(oldest.loadedAt) {
+				oldestName, oldest = name, entry
+			}
+		}
+
+		if oldest == nil {
+			// All entries are still loading, they are removed once loaded if they fail
+			return
+		}
+		delete(c.entries, oldestName)
+	}
+}
+
+func (e *addressListEntry) loaded() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *addressListEntry) expired() bool {
+	// An entry still loading is not expired
+	return e.loaded() && time.Since(e.loadedAt) > addressListCacheTTL
+}
+
+// addressListName returns the name, relative to the store, of the address list referenced by
+// url, which must either be that name or a full URL under the store
+func addressListName(store dstore.Store, url string) (string, error) {
+	name := url
+	if strings.Contains(url, "://") {
+		base := strings.TrimSuffix(store.BaseURL().String(), "/") + "/"
+		if !strings.HasPrefix(url, base) {
+			return "", fmt.Errorf("only address lists under the store configured on this server are accepted")
+		}
+		name = strings.TrimPrefix(url, base)
+	}
+
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return "", fmt.Errorf("invalid address list name")
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid address list name")
+		}
+	}
+	return name, nil
+}
+
+// readAddressList reads the addresses, in hex, separated by new lines, spaces or commas, of
+// the file named name in the store, lines starting with '#' being ignored. The errors never
+// contain the file's content, which is not the client's.
+func readAddressList(store dstore.Store, name string) ([]eth.Address, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), addressListLoadTimeout)
+	defer cancel()
+
+	reader, err := store.OpenObject(ctx, name)
+	if err != nil {
+		if errors.Is(err, dstore.ErrNotFound) {
+			return nil, fmt.Errorf("address list %q not found", name)
+		}
+
+		zlog.Warn("unable to open address list", zap.String("name", name), zap.Error(err))
+		return nil, fmt.Errorf("address list %q cannot be read", name)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, addressListMaxSize+1))
+	if err != nil {
+		zlog.Warn("unable to read address list", zap.String("name", name), zap.Error(err))
+		return nil, fmt.Errorf("address list %q cannot be read", name)
+	}
+
+	if len(content) > addressListMaxSize {
+		return nil, fmt.Errorf("address list %q is larger than %d bytes", name, addressListMaxSize)
+	}
+
+	var out []eth.Address
+	for i, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		for _, value := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' }) {
+			addr, err := eth.NewAddress(value)
+			if err != nil {
+				return nil, fmt.Errorf("address list %q: invalid address on line %d", name, i+1)
+			}
+			out = append(out, addr)
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("address list %q is empty", name)
+	}
+
+	zlog.Info("loaded address list", zap.String("name", name), zap.Int("address_count", len(out)))
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAddressListsStore configures a local store, removed at the end of the test, as the store
+// of the address lists
+func testAddressListsStore(t *testing.T) dstore.Store {
+	t.Helper()
+
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", true)
+	require.NoError(t, err)
+
+	ConfigureAddressLists(store)
+	t.Cleanup(func() { ConfigureAddressLists(nil) })
+	return store
+}
+
+// writeAddressList writes an address list in the configured store and returns its full URL
+func writeAddressList(t *testing.T, content string) string {
+	t.Helper()
+
+	store := addressLists.store
+	if store == nil {
+		store = testAddressListsStore(t)
+	}
+
+	name := fmt.Sprintf("lists/addresses-%d.txt", len(addressListFiles(t, store)))
+	require.NoError(t, store.WriteObject(context.Background(), name, strings.NewReader(content)))
+	return store.ObjectURL(name)
+}
+
+func addressListFiles(t *testing.T, store dstore.Store) []string {
+	files, err := store.ListFiles(context.Background(), "", -1)
+	require.NoError(t, err)
+	return files
+}
+
+func TestReadAddressList(t *testing.T) {
+	store := testAddressListsStore(t)
+	url := writeAddressList(t, "# tokens\n0xcccccccccccccccccccccccccccccccccccccccc, aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n\n0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\r\n")
+
+	addresses, err := addressLists.load(url)
+	require.NoError(t, err)
+	assert.Equal(t, []eth.Address{
+		tokenAddr,
+		eth.MustNewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+		eth.MustNewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+	}, addresses)
+
+	_, err = addressLists.load(writeAddressList(t, "# nothing\n"))
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = addressLists.load(writeAddressList(t, "0xcccc\n"))
+	assert.ErrorContains(t, err, "invalid address on line 1")
+
+	// The content of a file that is not an address list is never sent back
+	_, err = addressLists.load(writeAddressList(t, "secret-token=abcdef\n"))
+	assert.ErrorContains(t, err, "invalid address on line 1")
+	assert.NotContains(t, err.Error(), "secret")
+
+	// Lists can be referenced by their name relative to the store
+	name := strings.TrimPrefix(url, strings.TrimSuffix(store.BaseURL().String(), "/")+"/")
+	addresses, err = addressLists.load(name)
+	require.NoError(t, err)
+	assert.Len(t, addresses, 3)
+}
+
+func TestAddressListName(t *testing.T) {
+	store := testAddressListsStore(t)
+	base := strings.TrimSuffix(store.BaseURL().String(), "/")
+
+	for _, url := range []string{"file:///etc/passwd", "gs://internal-bucket/secrets.txt", base + "/../escape.txt", "lists/../../escape.txt", "/etc/passwd", "", base + "/"} {
+		_, err := addressLists.load(url)
+		assert.Error(t, err, url)
+	}
+
+	name, err := addressListName(store, base+"/lists/whales.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "lists/whales.txt", name)
+
+	ConfigureAddressLists(nil)
+	_, err = addressLists.load("lists/whales.txt")
+	assert.ErrorContains(t, err, "address lists are not enabled on this server")
+}
+
+func TestAddressListCache_Eviction(t *testing.T) {
+	testAddressListsStore(t)
+
+	for i := 0; i < addressListCacheMaxEntries+10; i++ {
+		_, err := addressLists.load(writeAddressList(t, "0xcccccccccccccccccccccccccccccccccccccccc\n"))
+		require.NoError(t, err)
+	}
+	assert.Len(t, addressLists.entries, addressListCacheMaxEntries)
+}
+
+func TestWithAddressList(t *testing.T) {
+	url := writeAddressList(t, "0xcccccccccccccccccccccccccccccccccccccccc\n0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\n")
+	other := eth.MustNewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	addresses, err := withAddressList([]eth.Address{other, tokenAddr}, url)
+	require.NoError(t, err)
+	assert.Equal(t, []eth.Address{other, tokenAddr, eth.MustNewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")}, addresses)
+
+	addresses, err = withAddressList([]eth.Address{other}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []eth.Address{other}, addresses)
+}
+
+func TestLogFilter_AddressesURL(t *testing.T) {
+	url := writeAddressList(t, "0xcccccccccccccccccccccccccccccccccccccccc\n")
+
+	f, err := NewLogFilter(&pbtransform.LogFilter{AddressesUrl: url})
+	require.NoError(t, err)
+	assert.Equal(t, []eth.Address{tokenAddr}, f.Addresses())
+	assert.True(t, f.matches(transferTrace(walletA, walletB)))
+
+	_, err = NewLogFilter(&pbtransform.LogFilter{AddressesUrl: url + ".missing"})
+	assert.Error(t, err)
+}
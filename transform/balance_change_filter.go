@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+type BalanceChangeFilter struct {
+	addresses []eth.Address
+	reasons   []pbeth.BalanceChange_Reason
+}
+
+func (f *BalanceChangeFilter) Addresses() []eth.Address {
+	return f.addresses
+}
+
+func (f *BalanceChangeFilter) Reasons() []pbeth.BalanceChange_Reason {
+	return f.reasons
+}
+
+func NewBalanceChangeFilter(in *pbtransform.BalanceChangeFilter) (*BalanceChangeFilter, error) {
+	if len(in.Addresses) == 0 && len(in.Reasons) == 0 {
+		return nil, fmt.Errorf("a balance change filter transform requires at-least one address or one reason")
+	}
+
+	f := &BalanceChangeFilter{
+		addresses: make([]eth.Address, 0, len(in.Addresses)),
+		reasons:   make([]pbeth.BalanceChange_Reason, 0, len(in.Reasons)),
+	}
+	for _, addr := range in.Addresses {
+		f.addresses = append(f.addresses, addr)
+	}
+	f.reasons = append(f.reasons, in.Reasons...)
+
+	return f, nil
+}
+
+func (p *BalanceChangeFilter) matchReason(reason pbeth.BalanceChange_Reason) bool {
+	if len(p.reasons) == 0 {
+		return true
+	}
+	for _, r := range p.reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BalanceChangeFilter) matchesChange(change *pbeth.BalanceChange) bool {
+	return matchAnyAddress(p.addresses, change.Address) && p.matchReason(change.Reason)
+}
+
+func (p *BalanceChangeFilter) matches(trace *pbeth.TransactionTrace) bool {
+	for _, call := range trace.Calls {
+		if p.matchesCall(call) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BalanceChangeFilter) matchesCall(call *pbeth.Call) bool {
+	for _, change := range call.BalanceChanges {
+		if p.matchesChange(change) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterBlockBalanceChanges keeps only the block level balance changes matching one of the filters
+func filterBlockBalanceChanges(changes []*pbeth.BalanceChange, filters []*BalanceChangeFilter) (out []*pbeth.BalanceChange) {
+	for _, change := range changes {
+		for _, f := range filters {
+			if f.matchesChange(change) {
+				out = append(out, change)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/dstore"
 	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
-	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -37,6 +36,11 @@ var HeaderOnlyTransformFactory = &transform.Factory{
 	},
 }
 
+// headerOnlyProjection keeps only the block's header and few top-level core fields
+var headerOnlyProjection = newKeepOnlyProjection("ver", "hash", "number", "size", "header")
+
+// HeaderOnlyFilter is the special case of FieldProjection dropping everything
+// except the block's header and few top-level core fields
 type HeaderOnlyFilter struct{}
 
 func (p *HeaderOnlyFilter) String() string {
@@ -44,27 +48,16 @@ func (p *HeaderOnlyFilter) String() string {
 }
 
 func (p *HeaderOnlyFilter) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
-
-	ethFullBlock := &pbeth.Block{}
-	err := readOnlyBlk.Payload.UnmarshalTo(ethFullBlock)
+	ethBlock, err := blockFromInput(readOnlyBlk, in)
 	if err != nil {
-		return nil, fmt.Errorf("mashalling block: %w", err)
+		return nil, err
 	}
 
 	zlog.Debug("running header only transformer",
-		zap.String("hash", hex.EncodeToString(ethFullBlock.Hash)),
-		zap.Uint64("num", ethFullBlock.Num()),
+		zap.String("hash", hex.EncodeToString(ethBlock.Hash)),
+		zap.Uint64("num", ethBlock.Num()),
 	)
 
-	// FIXME: The block is actually duplicated elsewhere which means that at this point,
-	//        we work on our own copy of the block. So we can re-write this code to avoid
-	//        all the extra allocation and simply nillify the values that we want to hide
-	//        instead
-	return &pbeth.Block{
-		Ver:    ethFullBlock.Ver,
-		Hash:   ethFullBlock.Hash,
-		Number: ethFullBlock.Number,
-		Size:   ethFullBlock.Size,
-		Header: ethFullBlock.Header,
-	}, nil
+	headerOnlyProjection.apply(ethBlock)
+	return ethBlock, nil
 }
@@ -17,7 +17,12 @@ var MultiCallToFilterMessageName = proto.MessageName(&pbtransform.MultiCallToFil
 
 type CallToFilter struct {
 	addresses  []eth.Address
+	addressSet addressSet
 	signatures []eth.Hash
+
+	callTypes     []pbeth.CallType
+	maxDepth      *uint32
+	succeededOnly bool
 }
 
 func (f *CallToFilter) Addresses() []eth.Address {
@@ -28,14 +33,30 @@ func (f *CallToFilter) Signatures() []eth.Hash {
 	return f.signatures
 }
 
+func (f *CallToFilter) CallTypes() []pbeth.CallType {
+	return f.callTypes
+}
+
+// MaxDepth returns the maximum depth of the calls to match, nil if unset
+func (f *CallToFilter) MaxDepth() *uint32 {
+	return f.maxDepth
+}
+
+func (f *CallToFilter) SucceededOnly() bool {
+	return f.succeededOnly
+}
+
 func NewCallToFilter(in *pbtransform.CallToFilter) (*CallToFilter, error) {
-	if len(in.Addresses) == 0 && len(in.Signatures) == 0 {
+	if len(in.Addresses) == 0 && in.AddressesUrl == "" && len(in.Signatures) == 0 {
 		return nil, fmt.Errorf("a call filter transform requires at-least one address or one method signature")
 	}
 
 	f := &CallToFilter{
-		addresses:  make([]eth.Address, 0, len(in.Addresses)),
-		signatures: make([]eth.Hash, 0, len(in.Signatures)),
+		addresses:     make([]eth.Address, 0, len(in.Addresses)),
+		signatures:    make([]eth.Hash, 0, len(in.Signatures)),
+		callTypes:     append([]pbeth.CallType(nil), in.CallTypes...),
+		maxDepth:      in.MaxDepth,
+		succeededOnly: in.SucceededOnly,
 	}
 	for _, addr := range in.Addresses {
 		f.addresses = append(f.addresses, addr)
@@ -44,6 +65,12 @@ func NewCallToFilter(in *pbtransform.CallToFilter) (*CallToFilter, error) {
 		f.signatures = append(f.signatures, sig)
 	}
 
+	var err error
+	if f.addresses, err = withAddressList(f.addresses, in.AddressesUrl); err != nil {
+		return nil, err
+	}
+	f.addressSet = newAddressSet(f.addresses)
+
 	return f, nil
 
 }
@@ -52,12 +79,7 @@ func (p *CallToFilter) matchAddress(src eth.Address) bool {
 	if len(p.addresses) == 0 {
 		return true
 	}
-	for _, addr := range p.addresses {
-		if bytes.Equal(addr, src) {
-			return true
-		}
-	}
-	return false
+	return p.addressSet.contains(src)
 }
 
 func (p *CallToFilter) matchSignature(src eth.Hash) bool {
@@ -72,15 +94,52 @@ func (p *CallToFilter) matchSignature(src eth.Hash) bool {
 	return false
 }
 
+func (p *CallToFilter) matchCallType(callType pbeth.CallType) bool {
+	if len(p.callTypes) == 0 {
+		return true
+	}
+	for _, t := range p.callTypes {
+		if t == callType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *CallToFilter) matchDepth(depth uint32) bool {
+	return p.maxDepth == nil || depth <= *p.maxDepth
+}
+
+func (p *CallToFilter) matchStatus(failed bool) bool {
+	return !p.succeededOnly || !failed
+}
+
 func (p *CallToFilter) matches(trace *pbeth.TransactionTrace) bool {
 	for _, call := range trace.Calls {
-		if p.matchAddress(call.Address) && p.matchSignature(call.Method()) {
+		if p.matchesCall(call) {
 			return true
 		}
 	}
 	return false
 }
 
+func (p *CallToFilter) matchesCall(call *pbeth.Call) bool {
+	return p.matchAddress(call.Address) && p.matchSignature(call.Method()) &&
+		p.matchCallType(call.CallType) && p.matchDepth(call.Depth) && p.matchStatus(call.StatusFailed)
+}
+
+// matchesBase matches the top-level transaction, used on BASE detail level blocks which have no calls,
+// the transaction being seen as the root call
+func (p *CallToFilter) matchesBase(trace *pbeth.TransactionTrace) bool {
+	callType := pbeth.CallType_CALL
+	if len(trace.To) == 0 {
+		callType = pbeth.CallType_CREATE
+	}
+
+	return p.matchAddress(trace.To) && p.matchSignature(trace.Method()) &&
+		p.matchCallType(callType) && p.matchDepth(0) && p.matchStatus(trace.Status != pbeth.TransactionTraceStatus_SUCCEEDED)
+}
+
 func NewMultiCallToFilterTransformFactory(indexStore dstore.Store, possibleIndexSizes []uint64) (*transform.Factory, error) {
 	return MultiCallToFilterTransformFactory(indexStore, possibleIndexSizes), nil
 }
@@ -101,7 +160,7 @@ func MultiCallToFilterTransformFactory(indexStore dstore.Store, possibleIndexSiz
 				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
 			}
 
-			return newCombinedFilter(filter.CallFilters, nil, indexStore, possibleIndexSizes, false)
+			return newCombinedFilter(&pbtransform.CombinedFilter{CallFilters: filter.CallFilters}, indexStore, possibleIndexSizes)
 		},
 	}
 }
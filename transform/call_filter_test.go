@@ -0,0 +1,53 @@
+package transform
+
+import (
+	"testing"
+
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCallToFilter_Constraints(t *testing.T) {
+	proxyCall := &pbeth.Call{Index: 1, CallType: pbeth.CallType_CALL, Address: routerAddr}
+	implementationCall := &pbeth.Call{Index: 2, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_DELEGATE, Address: botAddr}
+	failedCall := &pbeth.Call{Index: 3, ParentIndex: 2, Depth: 2, CallType: pbeth.CallType_STATIC, Address: botAddr, StatusFailed: true}
+
+	tests := []struct {
+		name     string
+		filter   *pbtransform.CallToFilter
+		call     *pbeth.Call
+		expected bool
+	}{
+		{"no constraint", &pbtransform.CallToFilter{Addresses: [][]byte{botAddr}}, failedCall, true},
+		{"call type matching", &pbtransform.CallToFilter{Addresses: [][]byte{botAddr}, CallTypes: []pbeth.CallType{pbeth.CallType_DELEGATE}}, implementationCall, true},
+		{"call type not matching", &pbtransform.CallToFilter{Addresses: [][]byte{routerAddr}, CallTypes: []pbeth.CallType{pbeth.CallType_DELEGATE}}, proxyCall, false},
+		{"root call only", &pbtransform.CallToFilter{Addresses: [][]byte{routerAddr}, MaxDepth: proto.Uint32(0)}, proxyCall, true},
+		{"deeper than max depth", &pbtransform.CallToFilter{Addresses: [][]byte{botAddr}, MaxDepth: proto.Uint32(0)}, implementationCall, false},
+		{"succeeded only on failed call", &pbtransform.CallToFilter{Addresses: [][]byte{botAddr}, SucceededOnly: true}, failedCall, false},
+		{"succeeded only on successful call", &pbtransform.CallToFilter{Addresses: [][]byte{botAddr}, SucceededOnly: true}, implementationCall, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewCallToFilter(test.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, f.matchesCall(test.call))
+		})
+	}
+}
+
+func TestCallToFilter_ConstraintsOnBase(t *testing.T) {
+	f, err := NewCallToFilter(&pbtransform.CallToFilter{Addresses: [][]byte{routerAddr}, MaxDepth: proto.Uint32(0), SucceededOnly: true, CallTypes: []pbeth.CallType{pbeth.CallType_CALL}})
+	require.NoError(t, err)
+
+	trace := transactionTrace(userAddr, routerAddr, nil)
+	trace.Status = pbeth.TransactionTraceStatus_SUCCEEDED
+	assert.True(t, f.matchesBase(trace))
+
+	trace.Status = pbeth.TransactionTraceStatus_REVERTED
+	assert.False(t, f.matchesBase(trace))
+}
@@ -0,0 +1,61 @@
+package transform
+
+import (
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+// callMatcher is implemented by the filters that can tell which individual calls
+// of a transaction they matched
+type callMatcher interface {
+	matchesCall(call *pbeth.Call) bool
+}
+
+// pruneCalls keeps in the trace only the calls matched by one of the matchers along with
+// their ancestors, the root call being always kept. Kept calls keep their original index so
+// that they can be correlated with unpruned data, their parent being always kept their parent
+// index stays valid.
+func pruneCalls(trace *pbeth.TransactionTrace, matchers []callMatcher) {
+	if len(trace.Calls) == 0 {
+		return
+	}
+
+	callsByIndex := make(map[uint32]*pbeth.Call, len(trace.Calls))
+	for _, call := range trace.Calls {
+		callsByIndex[call.Index] = call
+	}
+
+	keep := make(map[uint32]bool)
+	keep[trace.Calls[0].Index] = true
+
+	for _, call := range trace.Calls {
+		if !matchesAnyCall(call, matchers) {
+			continue
+		}
+
+		for current := call; current != nil && !keep[current.Index]; current = callsByIndex[current.ParentIndex] {
+			keep[current.Index] = true
+		}
+	}
+
+	if len(keep) == len(trace.Calls) {
+		return
+	}
+
+	calls := make([]*pbeth.Call, 0, len(keep))
+	for _, call := range trace.Calls {
+		if keep[call.Index] {
+			calls = append(calls, call)
+		}
+	}
+
+	trace.Calls = calls
+}
+
+func matchesAnyCall(call *pbeth.Call, matchers []callMatcher) bool {
+	for _, m := range matchers {
+		if m.matchesCall(call) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package transform
+
+import (
+	"testing"
+
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneCalls(t *testing.T) {
+	// Call tree:
+	//   1 router
+	//     2 user
+	//       3 token
+	//     4 bot
+	//       5 router
+	//     7 user (index 6 is missing on purpose, indexes are not required to be contiguous)
+	//       8 token (emits Transfer)
+	calls := []*pbeth.Call{
+		{Index: 1, ParentIndex: 0, Depth: 0, Address: routerAddr},
+		{Index: 2, ParentIndex: 1, Depth: 1, Address: userAddr},
+		{Index: 3, ParentIndex: 2, Depth: 2, Address: tokenAddr},
+		{Index: 4, ParentIndex: 1, Depth: 1, Address: botAddr},
+		{Index: 5, ParentIndex: 4, Depth: 2, Address: routerAddr},
+		{Index: 7, ParentIndex: 1, Depth: 1, Address: userAddr},
+		{Index: 8, ParentIndex: 7, Depth: 2, Address: tokenAddr, Logs: []*pbeth.Log{{Address: tokenAddr, Topics: [][]byte{transferSig, walletA, walletB}}}},
+	}
+	trace := &pbeth.TransactionTrace{Hash: []byte{0x01}, Calls: calls, Receipt: &pbeth.TransactionReceipt{Logs: calls[6].Logs}}
+
+	logFilter, err := NewLogFilter(&pbtransform.LogFilter{Topic2: [][]byte{walletB}})
+	require.NoError(t, err)
+	callFilter, err := NewCallToFilter(&pbtransform.CallToFilter{Addresses: [][]byte{botAddr}})
+	require.NoError(t, err)
+
+	pruneCalls(trace, []callMatcher{logFilter, callFilter})
+
+	type callRef struct{ index, parent uint32 }
+	var actual []callRef
+	for _, call := range trace.Calls {
+		actual = append(actual, callRef{call.Index, call.ParentIndex})
+	}
+
+	// Kept calls keep their original index and parent index
+	assert.Equal(t, []callRef{{1, 0}, {4, 1}, {7, 1}, {8, 7}}, actual)
+	assert.Equal(t, botAddr.Bytes(), trace.Calls[1].Address)
+	assert.Equal(t, tokenAddr.Bytes(), trace.Calls[3].Address)
+	assert.Len(t, trace.Receipt.Logs, 1)
+}
+
+func TestCombinedFilter_PruneCalls(t *testing.T) {
+	// Call tree:
+	//   1 router
+	//     2 user (credits the bot)
+	//       3 token
+	prunableTrace := func() *pbeth.TransactionTrace {
+		return &pbeth.TransactionTrace{
+			From:    userAddr,
+			To:      routerAddr,
+			Status:  pbeth.TransactionTraceStatus_SUCCEEDED,
+			Receipt: &pbeth.TransactionReceipt{},
+			Calls: []*pbeth.Call{
+				{Index: 1, ParentIndex: 0, Depth: 0, Caller: userAddr, Address: routerAddr},
+				{Index: 2, ParentIndex: 1, Depth: 1, Caller: routerAddr, Address: userAddr, BalanceChanges: []*pbeth.BalanceChange{
+					{Address: botAddr, NewValue: pbeth.NewBigInt(1), Reason: pbeth.BalanceChange_REASON_REWARD_MINE_BLOCK},
+				}},
+				{Index: 3, ParentIndex: 2, Depth: 2, Caller: userAddr, Address: tokenAddr},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		filter          *pbtransform.CombinedFilter
+		expectedIndexes []uint32
+	}{
+		{
+			"balance change filter keeps the call with the balance change",
+			&pbtransform.CombinedFilter{BalanceChangeFilters: []*pbtransform.BalanceChangeFilter{{Addresses: [][]byte{botAddr}}}},
+			[]uint32{1, 2},
+		},
+		{
+			"transaction filter keeps only the root call",
+			&pbtransform.CombinedFilter{TransactionFilters: []*pbtransform.TransactionFilter{{From: [][]byte{userAddr}}}},
+			[]uint32{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.filter.PruneCalls = true
+			filter, err := newCombinedFilter(test.filter, nil, nil)
+			require.NoError(t, err)
+
+			output, err := filter.Transform(testBlock(t, &pbeth.Block{Number: 1, Header: &pbeth.BlockHeader{Number: 1}, TransactionTraces: []*pbeth.TransactionTrace{prunableTrace()}}), nil)
+			require.NoError(t, err)
+
+			traces := output.(*pbeth.Block).TransactionTraces
+			require.Len(t, traces, 1)
+
+			var indexes []uint32
+			for _, call := range traces[0].Calls {
+				indexes = append(indexes, call.Index)
+			}
+			assert.Equal(t, test.expectedIndexes, indexes)
+		})
+	}
+}
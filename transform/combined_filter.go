@@ -3,7 +3,9 @@ package transform
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/streamingfast/bstream"
@@ -17,6 +19,7 @@ import (
 	firecore "github.com/streamingfast/firehose-core"
 	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
 	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -25,6 +28,30 @@ const IdxPrefixEmpty = "" //
 const IdxPrefixLog = "L"  // log prefix for combined index
 const IdxPrefixCall = "C" // call prefix for combined index
 
+const IdxPrefixTransaction = "T"      // transaction (TO address and method signature) prefix for combined index
+const IdxPrefixTransactionFrom = "TF" // transaction sender (FROM address) prefix for combined index
+
+const IdxPrefixBalanceChange = "B"        // balance change address prefix for combined index
+const IdxPrefixBalanceChangeReason = "BR" // balance change reason prefix for combined index
+
+const IdxPrefixStorageChange = "S"     // storage change contract address prefix for combined index
+const IdxPrefixStorageChangeKey = "SK" // storage change contract address followed by slot key prefix for combined index
+
+const IdxPrefixContractCreation = "N"          // contract creation prefix for combined index, alone or followed by the deployer address
+const IdxPrefixContractCreationCodeHash = "NC" // contract creation deployed code hash prefix for combined index
+
+const IdxPrefixValueTransfer = "V"           // value transfer prefix for combined index, marking blocks with at least one transfer
+const IdxPrefixValueTransferFrom = "VF"      // value transfer sender address prefix for combined index
+const IdxPrefixValueTransferTo = "VT"        // value transfer recipient address prefix for combined index
+const IdxPrefixValueTransferMagnitude = "VM" // value transfer amount magnitude (bit length of the amount in wei) prefix for combined index
+
+const IdxPrefixLogTopic1 = "LT1" // log indexed argument topic.1 prefix for combined index
+const IdxPrefixLogTopic2 = "LT2" // log indexed argument topic.2 prefix for combined index
+const IdxPrefixLogTopic3 = "LT3" // log indexed argument topic.3 prefix for combined index
+
+// idxPrefixLogTopics maps the indexed argument position (minus one) to its combined index prefix
+var idxPrefixLogTopics = [3]string{IdxPrefixLogTopic1, IdxPrefixLogTopic2, IdxPrefixLogTopic3}
+
 const CombinedIndexerShortName = "combined"
 
 type Indexer interface {
@@ -52,71 +79,228 @@ func CombinedFilterTransformFactory(indexStore dstore.Store, possibleIndexSizes
 				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
 			}
 
-			if len(filter.CallFilters) == 0 && len(filter.LogFilters) == 0 && !filter.SendAllBlockHeaders {
-				return nil, fmt.Errorf("a combined filter transform requires at-least one callto filter, one log filter or it must have have send_all_block_headers enabled")
+			if len(filter.CallFilters) == 0 && len(filter.LogFilters) == 0 && len(filter.TransactionFilters) == 0 && len(filter.BalanceChangeFilters) == 0 && len(filter.StorageChangeFilters) == 0 && len(filter.ContractCreationFilters) == 0 && len(filter.ValueTransferFilters) == 0 && len(filter.FilterGroups) == 0 &&
+				len(filter.ExcludeCallFilters) == 0 && len(filter.ExcludeLogFilters) == 0 && len(filter.ExcludeTransactionFilters) == 0 &&
+				!filter.SendAllBlockHeaders {
+				return nil, fmt.Errorf("a combined filter transform requires at-least one callto filter, one log filter, one transaction filter, one balance change filter, one storage change filter, one contract creation filter, one value transfer filter, one filter group, one exclusion filter or it must have have send_all_block_headers enabled")
 			}
 
-			return newCombinedFilter(filter.CallFilters, filter.LogFilters, indexStore, possibleIndexSizes, filter.SendAllBlockHeaders)
+			return newCombinedFilter(filter, indexStore, possibleIndexSizes)
 
 		},
 	}
 }
 
-func newCombinedFilter(pbCallToFilters []*pbtransform.CallToFilter, pbLogFilters []*pbtransform.LogFilter, indexStore dstore.Store, possibleIndexSizes []uint64, sendAllBlockHeaders bool) (*CombinedFilter, error) {
-	var callToFilters []*CallToFilter
-	if l := len(pbCallToFilters); l > 0 {
-		callToFilters = make([]*CallToFilter, l)
-		for i, in := range pbCallToFilters {
-			f, err := NewCallToFilter(in)
-			if err != nil {
-				return nil, err
-			}
-			callToFilters[i] = f
-		}
+func newCombinedFilter(in *pbtransform.CombinedFilter, indexStore dstore.Store, possibleIndexSizes []uint64) (*CombinedFilter, error) {
+	callToFilters, err := newFilters(in.CallFilters, NewCallToFilter)
+	if err != nil {
+		return nil, err
 	}
 
-	var logFilters []*LogFilter
+	logFilters, err := newFilters(in.LogFilters, NewLogFilter)
+	if err != nil {
+		return nil, err
+	}
 
-	if l := len(pbLogFilters); l > 0 {
-		logFilters = make([]*LogFilter, l)
-		for i, in := range pbLogFilters {
-			f, err := NewLogFilter(in)
-			if err != nil {
-				return nil, err
-			}
-			logFilters[i] = f
-		}
+	transactionFilters, err := newFilters(in.TransactionFilters, NewTransactionFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceChangeFilters, err := newFilters(in.BalanceChangeFilters, NewBalanceChangeFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	storageChangeFilters, err := newFilters(in.StorageChangeFilters, NewStorageChangeFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	contractCreationFilters, err := newFilters(in.ContractCreationFilters, NewContractCreationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTransferFilters, err := newFilters(in.ValueTransferFilters, NewValueTransferFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	filterGroups, err := newFilters(in.FilterGroups, NewFilterGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeCallToFilters, err := newFilters(in.ExcludeCallFilters, NewCallToFilter)
+	if err != nil {
+		return nil, fmt.Errorf("exclusion filter: %w", err)
+	}
+
+	excludeLogFilters, err := newFilters(in.ExcludeLogFilters, NewLogFilter)
+	if err != nil {
+		return nil, fmt.Errorf("exclusion filter: %w", err)
+	}
+
+	excludeTransactionFilters, err := newFilters(in.ExcludeTransactionFilters, NewTransactionFilter)
+	if err != nil {
+		return nil, fmt.Errorf("exclusion filter: %w", err)
 	}
 
 	f := &CombinedFilter{
-		CallToFilters:       callToFilters,
-		LogFilters:          logFilters,
-		indexStore:          indexStore,
-		possibleIndexSizes:  possibleIndexSizes,
-		sendAllBlockHeaders: sendAllBlockHeaders,
+		CallToFilters:             callToFilters,
+		LogFilters:                logFilters,
+		TransactionFilters:        transactionFilters,
+		BalanceChangeFilters:      balanceChangeFilters,
+		StorageChangeFilters:      storageChangeFilters,
+		ContractCreationFilters:   contractCreationFilters,
+		ValueTransferFilters:      valueTransferFilters,
+		FilterGroups:              filterGroups,
+		ExcludeCallToFilters:      excludeCallToFilters,
+		ExcludeLogFilters:         excludeLogFilters,
+		ExcludeTransactionFilters: excludeTransactionFilters,
+		indexStore:                indexStore,
+		possibleIndexSizes:        possibleIndexSizes,
+		sendAllBlockHeaders:       in.SendAllBlockHeaders,
+	}
+
+	f.included = appendFilters[traceFilter](nil, f.LogFilters)
+	f.included = appendFilters(f.included, f.CallToFilters)
+	f.included = appendFilters(f.included, f.TransactionFilters)
+	f.included = appendFilters(f.included, f.BalanceChangeFilters)
+	f.included = appendFilters(f.included, f.StorageChangeFilters)
+	f.included = appendFilters(f.included, f.ContractCreationFilters)
+	f.included = appendFilters(f.included, f.ValueTransferFilters)
+	f.included = appendFilters(f.included, f.FilterGroups)
+
+	f.excluded = appendFilters[traceFilter](nil, f.ExcludeLogFilters)
+	f.excluded = appendFilters(f.excluded, f.ExcludeCallToFilters)
+	f.excluded = appendFilters(f.excluded, f.ExcludeTransactionFilters)
+
+	f.includedCalls = callMatchers(f.included)
+	f.excludedCalls = callMatchers(f.excluded)
+
+	if in.PruneCalls {
+		// Filter groups cannot match individual calls, their own filters are used to prune instead
+		f.pruneCallsMatchers = append([]callMatcher(nil), f.includedCalls...)
+		for _, group := range f.FilterGroups {
+			f.pruneCallsMatchers = append(f.pruneCallsMatchers, callMatchers(group.members)...)
+		}
+		f.pruneCalls = true
+	}
+
+	if indexStore != nil && f.hasInclusionFilters() {
+		f.transactionPositions = newTransactionPositionsProvider(f, indexStore, possibleIndexSizes)
 	}
 
 	return f, nil
 }
 
+// traceFilter is implemented by all the filters selecting the transactions of a block
+type traceFilter interface {
+	matches(trace *pbeth.TransactionTrace) bool
+}
+
+// callMatchers returns the filters that can also match individual calls
+func callMatchers(filters []traceFilter) (out []callMatcher) {
+	for _, f := range filters {
+		if cm, ok := f.(callMatcher); ok {
+			out = append(out, cm)
+		}
+	}
+	return out
+}
+
+// baseTraceFilter is implemented by the filters matching differently on BASE detail level blocks,
+// whose transactions have no calls, those rely on the top-level transaction instead
+type baseTraceFilter interface {
+	matchesBase(trace *pbeth.TransactionTrace) bool
+}
+
+// newFilters converts the received protobuf filters into their matching implementation
+func newFilters[I any, O any](in []I, newFilter func(I) (O, error)) (out []O, err error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	out = make([]O, len(in))
+	for i, pbFilter := range in {
+		if out[i], err = newFilter(pbFilter); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// appendFilters appends the filters to out as the more generic interface I
+func appendFilters[I any, F any](out []I, filters []F) []I {
+	for _, f := range filters {
+		out = append(out, any(f).(I))
+	}
+	return out
+}
+
 type CombinedFilter struct {
-	CallToFilters []*CallToFilter
-	LogFilters    []*LogFilter
+	CallToFilters      []*CallToFilter
+	LogFilters         []*LogFilter
+	TransactionFilters []*TransactionFilter
+
+	// BalanceChangeFilters also reduce the block level balance changes to the matching ones
+	BalanceChangeFilters    []*BalanceChangeFilter
+	StorageChangeFilters    []*StorageChangeFilter
+	ContractCreationFilters []*ContractCreationFilter
+	ValueTransferFilters    []*ValueTransferFilter
+
+	FilterGroups []*FilterGroup
+
+	// ExcludeCallToFilters, ExcludeLogFilters and ExcludeTransactionFilters remove transactions
+	// that were included by the filters above (or all of them, when there is none)
+	ExcludeCallToFilters      []*CallToFilter
+	ExcludeLogFilters         []*LogFilter
+	ExcludeTransactionFilters []*TransactionFilter
+
+	included []traceFilter
+	excluded []traceFilter
+
+	// includedCalls and excludedCalls are the filters of included and excluded matching individual
+	// calls, used for system calls
+	includedCalls []callMatcher
+	excludedCalls []callMatcher
+
+	pruneCalls         bool
+	pruneCallsMatchers []callMatcher
 
 	indexStore         dstore.Store
 	possibleIndexSizes []uint64
 
+	// transactionPositions is set when the filter can use the index, the matching transactions
+	// of the blocks covered by a transaction positions index are then picked without a full scan
+	transactionPositions *transactionPositionsProvider
+
 	sendAllBlockHeaders bool
 }
 
 type EthCombinedIndexer struct {
 	BlockIndexer Indexer
+
+	// TransactionPositions, when set, receives the keys of each transaction along with the
+	// transaction's position, see TransactionPosition
+	TransactionPositions Indexer
 }
 
 func NewEthCombinedIndexer(indexStore dstore.Store, indexSize uint64) (firecore.BlockIndexer[*pbeth.Block], error) {
 	return NewEthCombinedIndexerLegacy(indexStore, indexSize), nil
 }
 
+// NewEthCombinedIndexerWithTransactionPositions also writes, next to each `combined` index file, a
+// `combinedtrx` index file recording the transactions of the block in which each key was seen,
+// letting the CombinedFilter pick the matching transactions without scanning the whole block
+func NewEthCombinedIndexerWithTransactionPositions(indexStore dstore.Store, indexSize uint64) (firecore.BlockIndexer[*pbeth.Block], error) {
+	indexer := NewEthCombinedIndexerLegacy(indexStore, indexSize)
+	indexer.TransactionPositions = newTransactionPositionsIndexer(indexStore, indexSize)
+	return indexer, nil
+}
+
 func NewEthCombinedIndexerLegacy(indexStore dstore.Store, indexSize uint64) *EthCombinedIndexer {
 	bi := transform.NewBlockIndexer(indexStore, indexSize, CombinedIndexerShortName)
 	return &EthCombinedIndexer{
@@ -126,41 +310,183 @@ func NewEthCombinedIndexerLegacy(indexStore dstore.Store, indexSize uint64) *Eth
 
 // ProcessBlock implements chain-specific logic for Ethereum pbbstream.Block's
 func (i *EthCombinedIndexer) ProcessBlock(blk *pbeth.Block) error {
+	base := blk.DetailLevel == pbeth.Block_DETAILLEVEL_BASE
+
+	withPositions := i.TransactionPositions != nil
+	if withPositions {
+		var blockKeys []string
+		if len(blk.TransactionTraces) > maxTransactionsPerBlock {
+			zlog.Warn("block has too many transactions for the transaction positions index, they will all be scanned", zap.Uint64("block_num", blk.Number), zap.Int("transaction_count", len(blk.TransactionTraces)))
+			blockKeys = []string{transactionPositionsUncoveredKey}
+			withPositions = false
+		}
+
+		// Makes sure the indexer sees every block, even the ones without transaction, so that its
+		// index files cover the same ranges as the block index ones
+		i.TransactionPositions.Add(blockKeys, TransactionPosition(blk.Number, 0))
+	}
+
 	keys := make(map[string]bool)
-	for _, trace := range blk.TransactionTraces {
-		for key := range callKeys(trace, IdxPrefixCall) {
+	for index, trace := range blk.TransactionTraces {
+		traceKeys := transactionTraceKeys(trace, base)
+		for key := range traceKeys {
 			keys[key] = true
 		}
-		for key := range logKeys(trace, IdxPrefixLog) {
+
+		if withPositions {
+			i.TransactionPositions.Add(keysArray(traceKeys), TransactionPosition(blk.Number, index))
+		}
+	}
+	for _, call := range blk.SystemCalls {
+		for key := range systemCallKeys(call) {
 			keys[key] = true
 		}
 	}
-	keyArray := make([]string, 0, len(keys))
-	for key := range keys {
-		keyArray = append(keyArray, key)
+	for key := range balanceChangeKeys(blk.BalanceChanges) {
+		keys[key] = true
 	}
 
-	i.BlockIndexer.Add(keyArray, blk.Number)
+	i.BlockIndexer.Add(keysArray(keys), blk.Number)
 	return nil
 }
 
+// transactionTraceKeys returns the keys of the transaction and of all its calls
+func transactionTraceKeys(trace *pbeth.TransactionTrace, base bool) map[string]bool {
+	keys := make(map[string]bool)
+	for key := range callKeys(trace, IdxPrefixCall, base) {
+		keys[key] = true
+	}
+	for key := range logKeys(trace.Receipt.Logs, IdxPrefixLog) {
+		keys[key] = true
+	}
+	for key := range transactionKeys(trace) {
+		keys[key] = true
+	}
+	for key := range valueTransferKeys(trace) {
+		keys[key] = true
+	}
+	for _, call := range trace.Calls {
+		for key := range balanceChangeKeys(call.BalanceChanges) {
+			keys[key] = true
+		}
+		for key := range storageChangeKeys(call) {
+			keys[key] = true
+		}
+		for key := range contractCreationKeys(call) {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+func keysArray(keys map[string]bool) []string {
+	out := make([]string, 0, len(keys))
+	for key := range keys {
+		out = append(out, key)
+	}
+	return out
+}
+
 func addSigString(in AddressSignatureFilter, limit int) string {
-	var addresses []string
-	var signatures []string
-	for i, a := range in.Addresses() {
+	return fmt.Sprintf("{addrs: %s, sigs: %s}", prettyAddresses(in.Addresses(), limit), prettyHashes(in.Signatures(), limit))
+}
+
+func callToFilterString(in *CallToFilter, limit int) string {
+	out := addSigString(in, limit)
+
+	var constraints []string
+	if len(in.CallTypes()) != 0 {
+		callTypes := make([]string, len(in.CallTypes()))
+		for i, callType := range in.CallTypes() {
+			callTypes[i] = callType.String()
+		}
+		constraints = append(constraints, fmt.Sprintf("callTypes: %s", strings.Join(callTypes, ",")))
+	}
+	if in.MaxDepth() != nil {
+		constraints = append(constraints, fmt.Sprintf("maxDepth: %d", *in.MaxDepth()))
+	}
+	if in.SucceededOnly() {
+		constraints = append(constraints, "succeededOnly: true")
+	}
+	if len(constraints) == 0 {
+		return out
+	}
+	return strings.TrimSuffix(out, "}") + ", " + strings.Join(constraints, ", ") + "}"
+}
+
+func logFilterString(in *LogFilter, limit int) string {
+	out := addSigString(in, limit)
+	if !in.hasTopics() {
+		return out
+	}
+
+	var topics []string
+	for position := 1; position <= 3; position++ {
+		if values := in.Topics(position); len(values) != 0 {
+			topics = append(topics, fmt.Sprintf("topic%d: %s", position, prettyHashes(values, limit)))
+		}
+	}
+	return strings.TrimSuffix(out, "}") + ", " + strings.Join(topics, ", ") + "}"
+}
+
+func transactionFilterString(in *TransactionFilter, limit int) string {
+	return fmt.Sprintf("{from: %s, to: %s, sigs: %s}", prettyAddresses(in.From(), limit), prettyAddresses(in.Addresses(), limit), prettyHashes(in.Signatures(), limit))
+}
+
+func balanceChangeFilterString(in *BalanceChangeFilter, limit int) string {
+	var reasons []string
+	for i, r := range in.Reasons() {
+		if i > limit {
+			break
+		}
+		reasons = append(reasons, r.String())
+	}
+	return fmt.Sprintf("{addrs: %s, reasons: %s}", prettyAddresses(in.Addresses(), limit), strings.Join(reasons, ","))
+}
+
+func storageChangeFilterString(in *StorageChangeFilter, limit int) string {
+	return fmt.Sprintf("{addrs: %s, keys: %s}", prettyAddresses(in.Addresses(), limit), prettyHashes(in.Keys(), limit))
+}
+
+func contractCreationFilterString(in *ContractCreationFilter, limit int) string {
+	var prefixes []string
+	for i, prefix := range in.CodeHashPrefixes() {
 		if i > limit {
 			break
 		}
-		addresses = append(addresses, a.Pretty())
+		prefixes = append(prefixes, eth.Hex(prefix).Pretty())
 	}
-	for i, s := range in.Signatures() {
+	return fmt.Sprintf("{deployers: %s, codeHashPrefixes: %s}", prettyAddresses(in.Deployers(), limit), strings.Join(prefixes, ","))
+}
+
+func valueTransferFilterString(in *ValueTransferFilter, limit int) string {
+	minValue := "none"
+	if in.MinValue() != nil {
+		minValue = in.MinValue().String()
+	}
+	return fmt.Sprintf("{addrs: %s, from: %s, to: %s, minValue: %s}", prettyAddresses(in.Addresses(), limit), prettyAddresses(in.From(), limit), prettyAddresses(in.To(), limit), minValue)
+}
+
+func prettyAddresses(in []eth.Address, limit int) string {
+	var out []string
+	for i, a := range in {
 		if i > limit {
 			break
 		}
-		signatures = append(signatures, s.Pretty())
+		out = append(out, a.Pretty())
 	}
-	return fmt.Sprintf("{addrs: %s, sigs: %s}", strings.Join(addresses, ","), strings.Join(signatures, ","))
+	return strings.Join(out, ",")
+}
 
+func prettyHashes(in []eth.Hash, limit int) string {
+	var out []string
+	for i, h := range in {
+		if i > limit {
+			break
+		}
+		out = append(out, h.Pretty())
+	}
+	return strings.Join(out, ",")
 }
 
 func truncate(in string, size int, suffix string) string {
@@ -180,53 +506,174 @@ func (f *CombinedFilter) String() string {
 		limit = 999999
 	}
 
-	callFilters := make([]string, len(f.CallToFilters))
-	for i, f := range f.CallToFilters {
-		callFilters[i] = addSigString(f, limit)
+	section := func(name string, value string) string {
+		if !debug {
+			value = truncate(value, 90, "...}")
+		}
+		return fmt.Sprintf("%s:[%s]", name, value)
 	}
-	logFilters := make([]string, len(f.LogFilters))
-	for i, f := range f.LogFilters {
-		logFilters[i] = addSigString(f, limit)
+
+	sections := []string{
+		section("Calls", filtersString(f.CallToFilters, limit, callToFilterString)),
+		section("Logs", filtersString(f.LogFilters, limit, logFilterString)),
 	}
 
-	if debug {
-		return fmt.Sprintf("Combined filter: Calls:[%s], Logs:[%s], SendAllBlockHeaders: %v", strings.Join(callFilters, ","), strings.Join(logFilters, ","), f.sendAllBlockHeaders)
+	// Optional sections are only displayed when not empty
+	optionals := []struct {
+		name  string
+		value string
+	}{
+		{"Transactions", filtersString(f.TransactionFilters, limit, transactionFilterString)},
+		{"BalanceChanges", filtersString(f.BalanceChangeFilters, limit, balanceChangeFilterString)},
+		{"StorageChanges", filtersString(f.StorageChangeFilters, limit, storageChangeFilterString)},
+		{"ContractCreations", filtersString(f.ContractCreationFilters, limit, contractCreationFilterString)},
+		{"ValueTransfers", filtersString(f.ValueTransferFilters, limit, valueTransferFilterString)},
+		{"Groups", filtersString(f.FilterGroups, limit, filterGroupString)},
+		{"ExcludeCalls", filtersString(f.ExcludeCallToFilters, limit, callToFilterString)},
+		{"ExcludeLogs", filtersString(f.ExcludeLogFilters, limit, logFilterString)},
+		{"ExcludeTransactions", filtersString(f.ExcludeTransactionFilters, limit, transactionFilterString)},
+	}
+	for _, optional := range optionals {
+		if optional.value != "" {
+			sections = append(sections, section(optional.name, optional.value))
+		}
+	}
+
+	if f.pruneCalls {
+		sections = append(sections, "PruneCalls: true")
 	}
 
-	return fmt.Sprintf("Combined filter: Calls:[%s], Logs:[%s], SendAllBlockHeaders: %v", truncate(strings.Join(callFilters, ","), 90, "...}"), truncate(strings.Join(logFilters, ","), 90, "...}"), f.sendAllBlockHeaders)
+	return fmt.Sprintf("Combined filter: %s, SendAllBlockHeaders: %v", strings.Join(sections, ", "), f.sendAllBlockHeaders)
 }
 
-func (f *CombinedFilter) matches(trace *pbeth.TransactionTrace) bool {
-	for _, lf := range f.LogFilters {
-		if lf.matches(trace) {
-			return true
-		}
+func filtersString[T any](filters []T, limit int, stringer func(T, int) string) string {
+	out := make([]string, len(filters))
+	for i, f := range filters {
+		out[i] = stringer(f, limit)
 	}
-	for _, cf := range f.CallToFilters {
-		if cf.matches(trace) {
+	return strings.Join(out, ",")
+}
+
+func (f *CombinedFilter) hasInclusionFilters() bool {
+	return len(f.included) != 0
+}
+
+func (f *CombinedFilter) hasExclusionFilters() bool {
+	return len(f.excluded) != 0
+}
+
+// matches is decided per block, through base, so that the same filter behaves sensibly on chains
+// mixing BASE and EXTENDED detail level blocks. Without inclusion filters, all transactions are
+// included only if exclusion filters are present, a filter with neither (`send_all_block_headers`
+// alone) matches no transaction and sends the block headers only.
+func (f *CombinedFilter) matches(trace *pbeth.TransactionTrace, base bool) bool {
+	if !f.hasInclusionFilters() && !f.hasExclusionFilters() {
+		return false
+	}
+
+	if f.hasInclusionFilters() && !matchesAny(trace, base, f.included) {
+		return false
+	}
+
+	return !matchesAny(trace, base, f.excluded)
+}
+
+// matchesSystemCall applies the filters able to match individual calls to a system call, those
+// are not part of any transaction so transaction filters never match them
+func (f *CombinedFilter) matchesSystemCall(call *pbeth.Call) bool {
+	if !f.hasInclusionFilters() && !f.hasExclusionFilters() {
+		return false
+	}
+
+	if f.hasInclusionFilters() && !matchesAnyCall(call, f.includedCalls) {
+		return false
+	}
+
+	return !matchesAnyCall(call, f.excludedCalls)
+}
+
+func matchesAny(trace *pbeth.TransactionTrace, base bool, filters []traceFilter) bool {
+	for _, f := range filters {
+		if matchesFilter(trace, base, f) {
 			return true
 		}
 	}
 	return false
 }
 
+func matchesFilter(trace *pbeth.TransactionTrace, base bool, f traceFilter) bool {
+	if base {
+		if bf, ok := f.(baseTraceFilter); ok {
+			return bf.matchesBase(trace)
+		}
+	}
+
+	return f.matches(trace)
+}
+
 func (f *CombinedFilter) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
-	ethBlock := &pbeth.Block{}
-	err := readOnlyBlk.Payload.UnmarshalTo(ethBlock)
+	ethBlock, err := blockFromInput(readOnlyBlk, in)
 	if err != nil {
-		return nil, fmt.Errorf("mashalling block: %w", err)
+		return nil, err
 	}
 
+	base := ethBlock.DetailLevel == pbeth.Block_DETAILLEVEL_BASE
+
+	// A block produced by a previous transform may not have the transactions of the indexed block
+	// at the same positions anymore, only the block decoded from the stream can use the index
+	decodedFromStream := in == nil || in.Obj() == nil
+
 	traces := []*pbeth.TransactionTrace{}
-	for _, trace := range ethBlock.TransactionTraces {
-		if f.matches(trace) {
+	for _, trace := range f.candidateTraces(ethBlock, decodedFromStream) {
+		if f.matches(trace, base) {
+			if f.pruneCalls {
+				pruneCalls(trace, f.pruneCallsMatchers)
+			}
 			traces = append(traces, trace)
 		}
 	}
 	ethBlock.TransactionTraces = traces
+
+	systemCalls := []*pbeth.Call{}
+	for _, call := range ethBlock.SystemCalls {
+		if f.matchesSystemCall(call) {
+			systemCalls = append(systemCalls, call)
+		}
+	}
+	ethBlock.SystemCalls = systemCalls
+
+	if len(f.BalanceChangeFilters) != 0 {
+		ethBlock.BalanceChanges = filterBlockBalanceChanges(ethBlock.BalanceChanges, f.BalanceChangeFilters)
+	}
+
 	return ethBlock, nil
 }
 
+// candidateTraces returns the transactions of the block that can match the filter, which are all
+// of them unless a transaction positions index covers the block and the block was decoded from
+// the stream, its transactions being then at the indexed positions. The index only selects the
+// candidates, the filter is still applied on them for the constraints not indexed and exclusions.
+func (f *CombinedFilter) candidateTraces(blk *pbeth.Block, decodedFromStream bool) []*pbeth.TransactionTrace {
+	if f.transactionPositions == nil || !decodedFromStream {
+		return blk.TransactionTraces
+	}
+
+	indexes, found := f.transactionPositions.transactionIndexes(blk.Number)
+	if !found {
+		return blk.TransactionTraces
+	}
+
+	out := make([]*pbeth.TransactionTrace, 0, len(indexes))
+	for _, index := range indexes {
+		if index >= len(blk.TransactionTraces) {
+			zlog.Warn("transaction positions index inconsistent with block, scanning all transactions", zap.Uint64("block_num", blk.Number), zap.Int("index", index), zap.Int("transaction_count", len(blk.TransactionTraces)))
+			return blk.TransactionTraces
+		}
+		out = append(out, blk.TransactionTraces[index])
+	}
+	return out
+}
+
 // GetIndexProvider will instantiate a new index conforming to the pbbstream.BlockIndexProvider interface
 func (f *CombinedFilter) GetIndexProvider() bstream.BlockIndexProvider {
 	if f.indexStore == nil {
@@ -237,7 +684,11 @@ func (f *CombinedFilter) GetIndexProvider() bstream.BlockIndexProvider {
 		return nil
 	}
 
-	if len(f.CallToFilters) == 0 && len(f.LogFilters) == 0 {
+	// Exclusion filters can only remove transactions from a block, they can never tell
+	// that a block has no match at all. The index is thus driven by the inclusion filters
+	// alone and exclusions are applied by Transform on the blocks it returns.
+	if !f.hasInclusionFilters() {
+		zlog.Debug("combined filter without inclusion filters cannot use the index, all blocks will be processed")
 		return nil
 	}
 
@@ -245,38 +696,86 @@ func (f *CombinedFilter) GetIndexProvider() bstream.BlockIndexProvider {
 		f.indexStore,
 		CombinedIndexerShortName,
 		f.possibleIndexSizes,
-		getcombinedFilterFunc(f.CallToFilters, f.LogFilters),
+		getcombinedFilterFunc(f),
 	)
 
 }
 
-func getcombinedFilterFunc(callFilters []*CallToFilter, logFilters []*LogFilter) func(transform.BitmapGetter) []uint64 {
+// getcombinedFilterFunc returns the blocks matching any of the inclusion filters of the combined filter,
+// exclusion filters are never considered since they cannot tell if a block has no match at all
+func getcombinedFilterFunc(combined *CombinedFilter) func(transform.BitmapGetter) []uint64 {
 	return func(bitmaps transform.BitmapGetter) (matchingBlocks []uint64) {
-		out := roaring64.NewBitmap()
-		for _, f := range logFilters {
-			fbit := filterBitmap(f, bitmaps, IdxPrefixLog)
-			out.Or(fbit)
-		}
-		for _, f := range callFilters {
-			fbit := filterBitmap(f, bitmaps, IdxPrefixCall)
-			out.Or(fbit)
-		}
-		return nilIfEmpty(out.ToArray())
+		return nilIfEmpty(combinedFilterBitmap(combined, bitmaps).ToArray())
 	}
 }
 
-func logKeys(trace *pbeth.TransactionTrace, prefix string) map[string]bool {
+// combinedFilterBitmap ORs the bitmaps of each inclusion filter of the combined filter, it works the
+// same on the bitmaps of block numbers as on the ones of transaction positions
+func combinedFilterBitmap(combined *CombinedFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	out := roaring64.NewBitmap()
+	for _, f := range combined.LogFilters {
+		fbit := logFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.CallToFilters {
+		fbit := filterBitmap(f, bitmaps, IdxPrefixCall)
+		out.Or(fbit)
+	}
+	for _, f := range combined.TransactionFilters {
+		fbit := transactionFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.BalanceChangeFilters {
+		fbit := balanceChangeFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.StorageChangeFilters {
+		fbit := storageChangeFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.ContractCreationFilters {
+		fbit := contractCreationFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.ValueTransferFilters {
+		fbit := valueTransferFilterBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	for _, f := range combined.FilterGroups {
+		fbit := filterGroupBitmap(f, bitmaps)
+		out.Or(fbit)
+	}
+	return out
+}
+
+func logKeys(logs []*pbeth.Log, prefix string) map[string]bool {
 	out := make(map[string]bool)
-	for _, log := range trace.Receipt.Logs {
+	for _, log := range logs {
 		out[prefix+hex.EncodeToString(log.Address)] = true
 		if len(log.Topics) != 0 {
 			out[prefix+hex.EncodeToString(log.Topics[0])] = true
 		}
+		for i := 1; i < len(log.Topics) && i <= len(idxPrefixLogTopics); i++ {
+			out[idxPrefixLogTopics[i-1]+hex.EncodeToString(log.Topics[i])] = true
+		}
 	}
 	return out
 }
-func callKeys(trace *pbeth.TransactionTrace, prefix string) map[string]bool {
+
+// callKeys indexes the address and method of each call of the transaction, on BASE detail level
+// blocks the calls are not available so the top-level transaction is indexed in their place
+func callKeys(trace *pbeth.TransactionTrace, prefix string, base bool) map[string]bool {
 	out := make(map[string]bool)
+	if base {
+		if len(trace.To) != 0 {
+			out[prefix+hex.EncodeToString(trace.To)] = true
+		}
+		if sig := trace.Method(); sig != nil {
+			out[prefix+hex.EncodeToString(sig)] = true
+		}
+		return out
+	}
+
 	for _, call := range trace.Calls {
 		out[prefix+hex.EncodeToString(call.Address)] = true
 		if sig := call.Method(); sig != nil {
@@ -286,6 +785,122 @@ func callKeys(trace *pbeth.TransactionTrace, prefix string) map[string]bool {
 	return out
 }
 
+// transactionKeys indexes the top-level transaction recipient and method signature under
+// IdxPrefixTransaction and its sender under IdxPrefixTransactionFrom
+func transactionKeys(trace *pbeth.TransactionTrace) map[string]bool {
+	out := make(map[string]bool)
+	if len(trace.From) != 0 {
+		out[IdxPrefixTransactionFrom+hex.EncodeToString(trace.From)] = true
+	}
+	if len(trace.To) != 0 {
+		out[IdxPrefixTransaction+hex.EncodeToString(trace.To)] = true
+	}
+	if sig := trace.Method(); sig != nil {
+		out[IdxPrefixTransaction+hex.EncodeToString(sig)] = true
+	}
+	return out
+}
+
+// balanceChangeKeys indexes the address of each balance change under IdxPrefixBalanceChange
+// and its reason under IdxPrefixBalanceChangeReason
+func balanceChangeKeys(changes []*pbeth.BalanceChange) map[string]bool {
+	out := make(map[string]bool)
+	for _, change := range changes {
+		out[IdxPrefixBalanceChange+hex.EncodeToString(change.Address)] = true
+		out[IdxPrefixBalanceChangeReason+change.Reason.String()] = true
+	}
+	return out
+}
+
+// storageChangeKeys indexes the contract address of each storage change of the call under
+// IdxPrefixStorageChange and the contract address followed by the slot key under IdxPrefixStorageChangeKey,
+// calls with a reverted state are skipped since their changes never made it to the chain's state
+func storageChangeKeys(call *pbeth.Call) map[string]bool {
+	out := make(map[string]bool)
+	if call.StateReverted {
+		return out
+	}
+
+	for _, change := range call.StorageChanges {
+		address := hex.EncodeToString(change.Address)
+		out[IdxPrefixStorageChange+address] = true
+		out[IdxPrefixStorageChangeKey+address+hex.EncodeToString(change.Key)] = true
+	}
+	return out
+}
+
+// contractCreationKeys marks the block under IdxPrefixContractCreation when the call deployed a contract,
+// also indexing the deployer under IdxPrefixContractCreation and the deployed code hash under
+// IdxPrefixContractCreationCodeHash
+func contractCreationKeys(call *pbeth.Call) map[string]bool {
+	out := make(map[string]bool)
+	if !isContractCreation(call) {
+		return out
+	}
+
+	out[IdxPrefixContractCreation] = true
+	out[IdxPrefixContractCreation+hex.EncodeToString(call.Caller)] = true
+	if codeHash := deployedCodeHash(call); len(codeHash) != 0 {
+		out[IdxPrefixContractCreationCodeHash+hex.EncodeToString(codeHash)] = true
+	}
+	return out
+}
+
+// valueTransferKeys indexes the sender, recipient and amount magnitude of the value transfers of
+// the transaction, relying on the transaction itself when its calls are not available
+func valueTransferKeys(trace *pbeth.TransactionTrace) map[string]bool {
+	out := make(map[string]bool)
+	if len(trace.Calls) == 0 {
+		if from, to, value, ok := transactionValueTransfer(trace); ok {
+			addValueTransferKeys(out, from, to, value)
+		}
+		return out
+	}
+
+	for _, call := range trace.Calls {
+		addCallValueTransferKeys(out, call)
+	}
+	return out
+}
+
+func addCallValueTransferKeys(out map[string]bool, call *pbeth.Call) {
+	if from, to, value, ok := callValueTransfer(call); ok {
+		addValueTransferKeys(out, from, to, value)
+	}
+	for _, transfer := range balanceChangeTransfers(call) {
+		addValueTransferKeys(out, transfer.from, transfer.to, transfer.value)
+	}
+}
+
+func addValueTransferKeys(out map[string]bool, from, to eth.Address, value *big.Int) {
+	out[IdxPrefixValueTransfer] = true
+	if len(from) != 0 {
+		out[IdxPrefixValueTransferFrom+hex.EncodeToString(from)] = true
+	}
+	if len(to) != 0 {
+		out[IdxPrefixValueTransferTo+hex.EncodeToString(to)] = true
+	}
+	out[IdxPrefixValueTransferMagnitude+strconv.Itoa(value.BitLen())] = true
+}
+
+// systemCallKeys indexes a system call under the same prefixes as the calls of transactions
+func systemCallKeys(call *pbeth.Call) map[string]bool {
+	out := logKeys(call.Logs, IdxPrefixLog)
+	out[IdxPrefixCall+hex.EncodeToString(call.Address)] = true
+	if sig := call.Method(); sig != nil {
+		out[IdxPrefixCall+hex.EncodeToString(sig)] = true
+	}
+
+	for _, keys := range []map[string]bool{balanceChangeKeys(call.BalanceChanges), storageChangeKeys(call), contractCreationKeys(call)} {
+		for key := range keys {
+			out[key] = true
+		}
+	}
+
+	addCallValueTransferKeys(out, call)
+	return out
+}
+
 type AddressSignatureFilter interface {
 	Addresses() []eth.Address
 	Signatures() []eth.Hash
@@ -312,16 +927,181 @@ func filterBitmap(f AddressSignatureFilter, bitmaps transform.BitmapGetter, idxP
 	}
 }
 
+// logFilterBitmap narrows down the address/signature bitmap of the log filter with
+// the bitmaps of each of its indexed topics constraints
+func logFilterBitmap(f *LogFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	var out *roaring64.Bitmap
+	if len(f.Addresses()) != 0 || len(f.Signatures()) != 0 {
+		out = filterBitmap(f, bitmaps, IdxPrefixLog)
+	}
+
+	for i, prefix := range idxPrefixLogTopics {
+		topics := f.Topics(i + 1)
+		if len(topics) == 0 {
+			continue
+		}
+
+		tbit := sigsBitmap(topics, bitmaps, prefix)
+		if out == nil {
+			out = tbit
+			continue
+		}
+		out.And(tbit)
+	}
+
+	if out == nil {
+		return roaring64.NewBitmap()
+	}
+	return out
+}
+
+// transactionFilterBitmap narrows down the recipient/signature bitmap of the transaction
+// filter with the bitmap of its senders
+func transactionFilterBitmap(f *TransactionFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	var out *roaring64.Bitmap
+	if len(f.Addresses()) != 0 || len(f.Signatures()) != 0 {
+		out = filterBitmap(f, bitmaps, IdxPrefixTransaction)
+	}
+
+	if len(f.From()) != 0 {
+		fromBit := addressBitmap(f.From(), bitmaps, IdxPrefixTransactionFrom)
+		if out == nil {
+			return fromBit
+		}
+		out.And(fromBit)
+	}
+
+	return out
+}
+
+// balanceChangeFilterBitmap intersects the addresses and reasons bitmaps of the balance change filter
+func balanceChangeFilterBitmap(f *BalanceChangeFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	var out *roaring64.Bitmap
+	if len(f.Addresses()) != 0 {
+		out = addressBitmap(f.Addresses(), bitmaps, IdxPrefixBalanceChange)
+	}
+
+	if len(f.Reasons()) != 0 {
+		reasonsBit := roaring64.NewBitmap()
+		for _, reason := range f.Reasons() {
+			if bm := bitmaps.Get(IdxPrefixBalanceChangeReason + reason.String()); bm != nil {
+				reasonsBit.Or(bm)
+			}
+		}
+
+		if out == nil {
+			return reasonsBit
+		}
+		out.And(reasonsBit)
+	}
+
+	return out
+}
+
+// storageChangeFilterBitmap looks up the contract addresses bitmap of the storage change filter, or
+// the bitmap of each contract address and slot key pair when keys are provided
+func storageChangeFilterBitmap(f *StorageChangeFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	if len(f.Keys()) == 0 {
+		return addressBitmap(f.Addresses(), bitmaps, IdxPrefixStorageChange)
+	}
+
+	out := roaring64.NewBitmap()
+	for _, addr := range f.Addresses() {
+		for _, key := range f.Keys() {
+			if bm := bitmaps.Get(IdxPrefixStorageChangeKey + addr.String() + key.String()); bm != nil {
+				out.Or(bm)
+			}
+		}
+	}
+	return out
+}
+
+// contractCreationFilterBitmap intersects the deployers and code hash prefixes bitmaps of the contract
+// creation filter, code hash prefixes being resolved by scanning the keys of the index
+func contractCreationFilterBitmap(f *ContractCreationFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	if len(f.Deployers()) == 0 && len(f.CodeHashPrefixes()) == 0 {
+		if bm := bitmaps.Get(IdxPrefixContractCreation); bm != nil {
+			return bm.Clone()
+		}
+		return roaring64.NewBitmap()
+	}
+
+	var out *roaring64.Bitmap
+	if len(f.Deployers()) != 0 {
+		out = addressBitmap(f.Deployers(), bitmaps, IdxPrefixContractCreation)
+	}
+
+	if len(f.CodeHashPrefixes()) != 0 {
+		codeHashBit := roaring64.NewBitmap()
+		for _, prefix := range f.CodeHashPrefixes() {
+			if bm := bitmaps.GetByPrefixAndSuffix(IdxPrefixContractCreationCodeHash+hex.EncodeToString(prefix), ""); bm != nil {
+				codeHashBit.Or(bm)
+			}
+		}
+
+		if out == nil {
+			return codeHashBit
+		}
+		out.And(codeHashBit)
+	}
+
+	return out
+}
+
+// valueTransferFilterBitmap intersects the bitmaps of each constraint of the value transfer filter,
+// the minimum value being resolved to all the magnitudes that can hold an amount greater or equal to it
+func valueTransferFilterBitmap(f *ValueTransferFilter, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	var constraints []*roaring64.Bitmap
+	if len(f.Addresses()) != 0 {
+		bm := addressBitmap(f.Addresses(), bitmaps, IdxPrefixValueTransferFrom)
+		bm.Or(addressBitmap(f.Addresses(), bitmaps, IdxPrefixValueTransferTo))
+		constraints = append(constraints, bm)
+	}
+	if len(f.From()) != 0 {
+		constraints = append(constraints, addressBitmap(f.From(), bitmaps, IdxPrefixValueTransferFrom))
+	}
+	if len(f.To()) != 0 {
+		constraints = append(constraints, addressBitmap(f.To(), bitmaps, IdxPrefixValueTransferTo))
+	}
+	if f.MinValue() != nil {
+		bm := roaring64.NewBitmap()
+		for bitLen := f.MinValue().BitLen(); bitLen <= 256; bitLen++ {
+			if magnitude := bitmaps.Get(IdxPrefixValueTransferMagnitude + strconv.Itoa(bitLen)); magnitude != nil {
+				bm.Or(magnitude)
+			}
+		}
+		constraints = append(constraints, bm)
+	}
+
+	if len(constraints) == 0 {
+		if bm := bitmaps.Get(IdxPrefixValueTransfer); bm != nil {
+			return bm.Clone()
+		}
+		return roaring64.NewBitmap()
+	}
+
+	out := constraints[0]
+	for _, bm := range constraints[1:] {
+		out.And(bm)
+	}
+	return out
+}
+
 // addressBitmap attempts to find the blockNums corresponding to the provided eth.Address
+//
+// The bitmaps found are collected first and unioned at once, which avoids growing an empty
+// bitmap one address at a time for the large lists loaded from an address list URL.
 func addressBitmap(addrs []eth.Address, bitmaps transform.BitmapGetter, idxPrefix string) *roaring64.Bitmap {
-	out := roaring64.NewBitmap()
+	found := make([]*roaring64.Bitmap, 0, len(addrs))
 	for _, addr := range addrs {
-		addrString := idxPrefix + addr.String()
-		if bm := bitmaps.Get(addrString); bm != nil {
-			out.Or(bm)
+		if bm := bitmaps.Get(idxPrefix + addr.String()); bm != nil {
+			found = append(found, bm)
 		}
 	}
-	return out
+	if len(found) == 0 {
+		return roaring64.NewBitmap()
+	}
+	return roaring64.FastOr(found...)
 }
 
 // sigsBitmap attemps to find the blockNums corresponding to the provided eth.Hash
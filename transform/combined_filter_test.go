@@ -4,14 +4,71 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/eth-go"
 	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/test-go/testify/require"
 )
 
+var (
+	implementationSlot = eth.MustNewHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	adminSlot          = eth.MustNewHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	factoryAddr        = eth.MustNewAddress("0xdddddddddddddddddddddddddddddddddddddddd")
+	deployedAddr       = eth.MustNewAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")
+	poolCodeHash       = eth.MustNewHash("0xabcdef0000000000000000000000000000000000000000000000000000000001")
+	otherHash          = eth.MustNewHash("0x1234560000000000000000000000000000000000000000000000000000000002")
+)
+
+func balanceChangeTrace(address []byte, reason pbeth.BalanceChange_Reason) *pbeth.TransactionTrace {
+	return testTrace(nil, nil, &pbeth.Call{BalanceChanges: []*pbeth.BalanceChange{{Address: address, Reason: reason}}})
+}
+
+func storageChangeTrace(reverted bool, address eth.Address, key eth.Hash) *pbeth.TransactionTrace {
+	return testTrace(nil, nil, &pbeth.Call{StateReverted: reverted, StorageChanges: []*pbeth.StorageChange{{Address: address, Key: key}}})
+}
+
+// contractCreationTrace is a transaction calling the deployer, which creates deployedAddr
+func contractCreationTrace(reverted bool, deployer eth.Address, codeHash eth.Hash) *pbeth.TransactionTrace {
+	return testTrace(userAddr, deployer,
+		&pbeth.Call{CallType: pbeth.CallType_CALL, Caller: userAddr, Address: deployer},
+		&pbeth.Call{CallType: pbeth.CallType_CREATE, Caller: deployer, Address: deployedAddr, StateReverted: reverted, CodeChanges: []*pbeth.CodeChange{{Address: deployedAddr, NewHash: codeHash}}},
+	)
+}
+
+// internalTransferTrace is a transaction from userAddr to routerAddr, the router forwarding value to the recipient
+func internalTransferTrace(callType pbeth.CallType, reverted bool, to eth.Address, value int64) *pbeth.TransactionTrace {
+	return testTrace(userAddr, routerAddr,
+		&pbeth.Call{CallType: pbeth.CallType_CALL, Caller: userAddr, Address: routerAddr},
+		&pbeth.Call{CallType: callType, Caller: routerAddr, Address: to, Value: pbeth.NewBigInt(value), StateReverted: reverted},
+	)
+}
+
+func baseTransferTrace(status pbeth.TransactionTraceStatus, to eth.Address, value int64) *pbeth.TransactionTrace {
+	trace := testTrace(userAddr, to)
+	trace.Value = pbeth.NewBigInt(value)
+	trace.Status = status
+	return trace
+}
+
+// balanceTransferTrace is a transaction whose root call moves value from routerAddr to the
+// recipient without a value carrying call, as only recorded by its balance changes
+func balanceTransferTrace(reason pbeth.BalanceChange_Reason, to eth.Address, value int64) *pbeth.TransactionTrace {
+	return testTrace(userAddr, routerAddr, &pbeth.Call{CallType: pbeth.CallType_CALL, Caller: userAddr, Address: routerAddr, BalanceChanges: []*pbeth.BalanceChange{
+		{Address: routerAddr, OldValue: pbeth.NewBigInt(value + 100), NewValue: pbeth.NewBigInt(100), Reason: reason},
+		{Address: to, NewValue: pbeth.NewBigInt(value), Reason: reason},
+	}})
+}
+
+// tracesBlock is a block made of the given transactions
+func tracesBlock(num uint64, traces ...*pbeth.TransactionTrace) *pbeth.Block {
+	return &pbeth.Block{Number: num, TransactionTraces: traces}
+}
+
 func TestString(t *testing.T) {
-	c, err := newCombinedFilter(nil, nil, nil, nil, false)
+	c, err := newCombinedFilter(&pbtransform.CombinedFilter{}, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, "Combined filter: Calls:[], Logs:[], SendAllBlockHeaders: false", c.String())
 
@@ -36,7 +93,307 @@ func TestString(t *testing.T) {
 		EventSignatures: nil,
 	}
 
-	c, err = newCombinedFilter([]*pbtransform.CallToFilter{cf, cf2}, []*pbtransform.LogFilter{lf1, lf2, lf3}, nil, nil, true)
+	c, err = newCombinedFilter(&pbtransform.CombinedFilter{CallFilters: []*pbtransform.CallToFilter{cf, cf2}, LogFilters: []*pbtransform.LogFilter{lf1, lf2, lf3}, SendAllBlockHeaders: true}, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, "Combined filter: Calls:[{addrs: 0xdeadbeef, sigs: 0xbbbb},{addrs: 0x9999999999999999999999999999999999999999999999...}], Logs:[{addrs: 0xdeadbeef, sigs: 0xbbbb},{addrs: 0xcccc2222, sigs: },{addrs: 0x999999999999999999...}], SendAllBlockHeaders: true", c.String())
 }
+
+func TestCombinedFilter_Exclusions(t *testing.T) {
+	spamToken := eth.MustNewAddress("0xdddddddddddddddddddddddddddddddddddddddd")
+	spamTrace := &pbeth.TransactionTrace{
+		Receipt: &pbeth.TransactionReceipt{
+			Logs: []*pbeth.Log{{Address: spamToken, Topics: [][]byte{transferSig, walletA, walletB}}},
+		},
+	}
+
+	c, err := newCombinedFilter(&pbtransform.CombinedFilter{
+		ExcludeLogFilters: []*pbtransform.LogFilter{{Addresses: [][]byte{spamToken}}},
+	}, dstore.NewMockStore(nil), nil)
+	require.NoError(t, err)
+	assert.True(t, c.matches(transferTrace(walletA, walletB), false))
+	assert.False(t, c.matches(spamTrace, false))
+	assert.Nil(t, c.GetIndexProvider(), "exclusions only cannot use the index")
+
+	c, err = newCombinedFilter(&pbtransform.CombinedFilter{
+		LogFilters:        []*pbtransform.LogFilter{{EventSignatures: [][]byte{transferSig}}},
+		ExcludeLogFilters: []*pbtransform.LogFilter{{Addresses: [][]byte{tokenAddr}}},
+	}, dstore.NewMockStore(nil), nil)
+	require.NoError(t, err)
+	assert.False(t, c.matches(transferTrace(walletA, walletB), false))
+	assert.True(t, c.matches(spamTrace, false))
+	assert.NotNil(t, c.GetIndexProvider(), "inclusion filters should drive the index")
+	assert.Equal(t, "Combined filter: Calls:[], Logs:[{addrs: , sigs: 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef}], ExcludeLogs:[{addrs: 0xcccccccccccccccccccccccccccccccccccccccc, sigs: }], SendAllBlockHeaders: false", c.String())
+}
+
+func TestCombinedFilter_BaseDetailLevel(t *testing.T) {
+	swapInput := append(swapSig.Bytes(), make([]byte, 32)...)
+	newBlock := func(num uint64, detailLevel pbeth.Block_DetailLevel, trace *pbeth.TransactionTrace) *pbeth.Block {
+		return &pbeth.Block{Number: num, DetailLevel: detailLevel, Header: &pbeth.BlockHeader{}, TransactionTraces: []*pbeth.TransactionTrace{trace}}
+	}
+
+	// Same top-level transaction on both sides of a BASE/EXTENDED boundary, the EXTENDED one having its calls
+	extendedTrace := transactionTrace(userAddr, routerAddr, swapInput)
+	extendedTrace.Calls = []*pbeth.Call{{Index: 1, Caller: userAddr, Address: routerAddr, Input: swapInput}}
+	blocks := []*pbeth.Block{
+		newBlock(10, pbeth.Block_DETAILLEVEL_BASE, transactionTrace(userAddr, routerAddr, swapInput)),
+		newBlock(11, pbeth.Block_DETAILLEVEL_EXTENDED, extendedTrace),
+		newBlock(12, pbeth.Block_DETAILLEVEL_BASE, transactionTrace(userAddr, botAddr, nil)),
+	}
+
+	bitmaps := testBitmaps{}
+	indexer := &EthCombinedIndexer{BlockIndexer: bitmaps}
+	for _, blk := range blocks {
+		require.NoError(t, indexer.ProcessBlock(blk))
+	}
+
+	filter := &pbtransform.CombinedFilter{
+		CallFilters: []*pbtransform.CallToFilter{{Addresses: [][]byte{routerAddr}, Signatures: [][]byte{swapSig}}},
+	}
+	assert.Equal(t, []uint64{10, 11}, testIndexMatches(t, bitmaps, filter))
+
+	c, err := newCombinedFilter(filter, nil, nil)
+	require.NoError(t, err)
+
+	for _, blk := range blocks {
+		output, err := c.Transform(testBlock(t, blk), nil)
+		require.NoError(t, err)
+
+		expected := 0
+		if blk.Number != 12 {
+			expected = 1
+		}
+		assert.Len(t, output.(*pbeth.Block).TransactionTraces, expected, "block #%d", blk.Number)
+	}
+}
+
+func TestCombinedFilter_SystemCalls(t *testing.T) {
+	beaconRoots := eth.MustNewAddress("0x000f3df6d732807ef1319fb7b8bb8522d0beac02")
+	historyStorage := eth.MustNewAddress("0x0aae40965e6800cd9b1f4b05ff21581047e3f91e")
+	block := &pbeth.Block{
+		Number: 10,
+		Header: &pbeth.BlockHeader{},
+		SystemCalls: []*pbeth.Call{
+			{Index: 1, Address: beaconRoots},
+			{Index: 1, Address: historyStorage, Logs: []*pbeth.Log{{Address: historyStorage, Topics: [][]byte{transferSig}}}},
+		},
+		TransactionTraces: []*pbeth.TransactionTrace{transferTrace(walletA, walletB)},
+	}
+
+	bitmaps := testBitmaps{}
+	require.NoError(t, (&EthCombinedIndexer{BlockIndexer: bitmaps}).ProcessBlock(block))
+
+	tests := []struct {
+		name                string
+		filter              *pbtransform.CombinedFilter
+		expectedSystemCalls []eth.Address
+		expectedTraces      int
+	}{
+		{
+			"call filter on system call",
+			&pbtransform.CombinedFilter{CallFilters: []*pbtransform.CallToFilter{{Addresses: [][]byte{beaconRoots}}}},
+			[]eth.Address{beaconRoots},
+			0,
+		},
+		{
+			"log filter on system emitted log",
+			&pbtransform.CombinedFilter{LogFilters: []*pbtransform.LogFilter{{Addresses: [][]byte{historyStorage}}}},
+			[]eth.Address{historyStorage},
+			0,
+		},
+		{
+			"log filter matching transactions and system calls",
+			&pbtransform.CombinedFilter{LogFilters: []*pbtransform.LogFilter{{EventSignatures: [][]byte{transferSig}}}},
+			[]eth.Address{historyStorage},
+			1,
+		},
+		{
+			"exclusions only",
+			&pbtransform.CombinedFilter{ExcludeCallFilters: []*pbtransform.CallToFilter{{Addresses: [][]byte{beaconRoots}}}},
+			[]eth.Address{historyStorage},
+			1,
+		},
+		{
+			"send all block headers only",
+			&pbtransform.CombinedFilter{SendAllBlockHeaders: true},
+			nil,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newCombinedFilter(test.filter, nil, nil)
+			require.NoError(t, err)
+
+			if c.hasInclusionFilters() {
+				assert.Equal(t, []uint64{10}, getcombinedFilterFunc(c)(bitmaps))
+			}
+
+			output, err := c.Transform(testBlock(t, block), nil)
+			require.NoError(t, err)
+
+			filtered := output.(*pbeth.Block)
+			var systemCalls []eth.Address
+			for _, call := range filtered.SystemCalls {
+				systemCalls = append(systemCalls, call.Address)
+			}
+			assert.Equal(t, test.expectedSystemCalls, systemCalls)
+			assert.Len(t, filtered.TransactionTraces, test.expectedTraces)
+		})
+	}
+}
+
+func TestCombinedFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *pbtransform.CombinedFilter
+		trace    *pbeth.TransactionTrace
+		expected bool
+	}{
+		{"storage change", &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}, Keys: [][]byte{implementationSlot}}}}, storageChangeTrace(false, routerAddr, implementationSlot), true},
+		{"storage change other key", &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}, Keys: [][]byte{implementationSlot}}}}, storageChangeTrace(false, routerAddr, adminSlot), false},
+		{"storage change other address", &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}, Keys: [][]byte{implementationSlot}}}}, storageChangeTrace(false, userAddr, implementationSlot), false},
+		{"storage change reverted", &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}, Keys: [][]byte{implementationSlot}}}}, storageChangeTrace(true, routerAddr, implementationSlot), false},
+
+		{"any deployment", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{}}}, contractCreationTrace(false, factoryAddr, poolCodeHash), true},
+		{"reverted deployment", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{}}}, contractCreationTrace(true, factoryAddr, poolCodeHash), false},
+		{"deployer matching", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{Deployers: [][]byte{factoryAddr}}}}, contractCreationTrace(false, factoryAddr, poolCodeHash), true},
+		{"deployer not matching", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{Deployers: [][]byte{routerAddr}}}}, contractCreationTrace(false, factoryAddr, poolCodeHash), false},
+		{"code hash prefix matching", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{CodeHashPrefixes: [][]byte{poolCodeHash[:3]}}}}, contractCreationTrace(false, factoryAddr, poolCodeHash), true},
+		{"code hash prefix not matching", &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{Deployers: [][]byte{factoryAddr}, CodeHashPrefixes: [][]byte{poolCodeHash[:3]}}}}, contractCreationTrace(false, factoryAddr, otherHash), false},
+
+		{"any internal transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), true},
+		{"reverted internal transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, internalTransferTrace(pbeth.CallType_CALL, true, botAddr, 10), false},
+		{"delegate call value", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, internalTransferTrace(pbeth.CallType_DELEGATE, false, botAddr, 10), false},
+		{"zero value call", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 0), false},
+		{"transfer address as recipient", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{Addresses: [][]byte{botAddr}}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), true},
+		{"transfer address as sender", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{Addresses: [][]byte{routerAddr}}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), true},
+		{"transfer from not matching", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{From: [][]byte{botAddr}}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), false},
+		{"transfer above threshold", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{MinValue: pbeth.NewBigInt(10)}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), true},
+		{"transfer below threshold", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{MinValue: pbeth.NewBigInt(11)}}}, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 10), false},
+		{"base block transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{To: [][]byte{botAddr}}}}, baseTransferTrace(pbeth.TransactionTraceStatus_SUCCEEDED, botAddr, 10), true},
+		{"base block failed transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{To: [][]byte{botAddr}}}}, baseTransferTrace(pbeth.TransactionTraceStatus_REVERTED, botAddr, 10), false},
+		{"balance change transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{From: [][]byte{routerAddr}, To: [][]byte{botAddr}, MinValue: pbeth.NewBigInt(10)}}}, balanceTransferTrace(pbeth.BalanceChange_REASON_TRANSFER, botAddr, 10), true},
+		{"balance change transfer below threshold", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{MinValue: pbeth.NewBigInt(11)}}}, balanceTransferTrace(pbeth.BalanceChange_REASON_TRANSFER, botAddr, 10), false},
+		{"balance override not a transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{To: [][]byte{botAddr}}}}, balanceTransferTrace(pbeth.BalanceChange_REASON_CALL_BALANCE_OVERRIDE, botAddr, 10), false},
+		{"balance change not a transfer", &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, balanceTransferTrace(pbeth.BalanceChange_REASON_GAS_BUY, botAddr, 10), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newCombinedFilter(test.filter, nil, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, c.matches(test.trace, false))
+		})
+	}
+}
+
+func TestCombinedFilter_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *pbtransform.CombinedFilter
+	}{
+		{"storage change keys without address", &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Keys: [][]byte{implementationSlot}}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newCombinedFilter(test.filter, nil, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCombinedFilter_Index(t *testing.T) {
+	balanceChangeBlocks := []*pbeth.Block{
+		tracesBlock(10, balanceChangeTrace(userAddr, pbeth.BalanceChange_REASON_TRANSFER)),
+		{Number: 11, BalanceChanges: []*pbeth.BalanceChange{{Address: userAddr, Reason: pbeth.BalanceChange_REASON_WITHDRAWAL}}},
+		{Number: 12, BalanceChanges: []*pbeth.BalanceChange{{Address: botAddr, Reason: pbeth.BalanceChange_REASON_WITHDRAWAL}}},
+	}
+	storageChangeBlocks := []*pbeth.Block{
+		tracesBlock(10, storageChangeTrace(false, routerAddr, implementationSlot)),
+		tracesBlock(11, storageChangeTrace(false, routerAddr, adminSlot)),
+		tracesBlock(12, storageChangeTrace(true, routerAddr, implementationSlot)),
+	}
+	contractCreationBlocks := []*pbeth.Block{
+		tracesBlock(10, contractCreationTrace(false, factoryAddr, poolCodeHash)),
+		tracesBlock(11, contractCreationTrace(false, routerAddr, otherHash)),
+		tracesBlock(12, contractCreationTrace(true, factoryAddr, poolCodeHash)),
+		tracesBlock(13, transactionTrace(userAddr, routerAddr, nil)),
+	}
+	valueTransferBlocks := []*pbeth.Block{
+		tracesBlock(10, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 1_000)),
+		tracesBlock(11, internalTransferTrace(pbeth.CallType_CALL, false, botAddr, 1_000_000)),
+		tracesBlock(12, internalTransferTrace(pbeth.CallType_CALL, true, botAddr, 1_000_000)),
+		tracesBlock(13, baseTransferTrace(pbeth.TransactionTraceStatus_SUCCEEDED, botAddr, 5)),
+		tracesBlock(14, balanceTransferTrace(pbeth.BalanceChange_REASON_TRANSFER, botAddr, 7)),
+	}
+
+	tests := []struct {
+		name     string
+		blocks   []*pbeth.Block
+		filter   *pbtransform.CombinedFilter
+		expected []uint64
+	}{
+		{"balance change address", balanceChangeBlocks, &pbtransform.CombinedFilter{BalanceChangeFilters: []*pbtransform.BalanceChangeFilter{{Addresses: [][]byte{userAddr}}}}, []uint64{10, 11}},
+		{"balance change reason", balanceChangeBlocks, &pbtransform.CombinedFilter{BalanceChangeFilters: []*pbtransform.BalanceChangeFilter{{Reasons: []pbeth.BalanceChange_Reason{pbeth.BalanceChange_REASON_WITHDRAWAL}}}}, []uint64{11, 12}},
+		{"balance change address and reason", balanceChangeBlocks, &pbtransform.CombinedFilter{BalanceChangeFilters: []*pbtransform.BalanceChangeFilter{{Addresses: [][]byte{userAddr}, Reasons: []pbeth.BalanceChange_Reason{pbeth.BalanceChange_REASON_WITHDRAWAL}}}}, []uint64{11}},
+
+		{"storage change address", storageChangeBlocks, &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}}}}, []uint64{10, 11}},
+		{"storage change address and key", storageChangeBlocks, &pbtransform.CombinedFilter{StorageChangeFilters: []*pbtransform.StorageChangeFilter{{Addresses: [][]byte{routerAddr}, Keys: [][]byte{implementationSlot}}}}, []uint64{10}},
+
+		{"any deployment", contractCreationBlocks, &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{}}}, []uint64{10, 11}},
+		{"deployer", contractCreationBlocks, &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{Deployers: [][]byte{factoryAddr}}}}, []uint64{10}},
+		{"code hash prefix", contractCreationBlocks, &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{CodeHashPrefixes: [][]byte{otherHash[:2]}}}}, []uint64{11}},
+		{"deployer and code hash prefix", contractCreationBlocks, &pbtransform.CombinedFilter{ContractCreationFilters: []*pbtransform.ContractCreationFilter{{Deployers: [][]byte{routerAddr}, CodeHashPrefixes: [][]byte{poolCodeHash[:2]}}}}, nil},
+
+		{"any value transfer", valueTransferBlocks, &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{}}}, []uint64{10, 11, 13, 14}},
+		{"value transfer address", valueTransferBlocks, &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{Addresses: [][]byte{routerAddr}}}}, []uint64{10, 11, 14}},
+		{"value transfer recipient and threshold", valueTransferBlocks, &pbtransform.CombinedFilter{ValueTransferFilters: []*pbtransform.ValueTransferFilter{{To: [][]byte{botAddr}, MinValue: pbeth.NewBigInt(500_000)}}}, []uint64{11}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bitmaps := testBitmaps{}
+			indexer := &EthCombinedIndexer{BlockIndexer: bitmaps}
+			for _, blk := range test.blocks {
+				require.NoError(t, indexer.ProcessBlock(blk))
+			}
+
+			assert.Equal(t, test.expected, testIndexMatches(t, bitmaps, test.filter))
+		})
+	}
+}
+
+func TestCombinedFilter_BlockBalanceChanges(t *testing.T) {
+	f, err := newCombinedFilter(&pbtransform.CombinedFilter{
+		BalanceChangeFilters: []*pbtransform.BalanceChangeFilter{
+			{Addresses: [][]byte{userAddr}},
+			{Reasons: []pbeth.BalanceChange_Reason{pbeth.BalanceChange_REASON_WITHDRAWAL}},
+		},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	block := &pbeth.Block{
+		Number: 10,
+		Header: &pbeth.BlockHeader{},
+		TransactionTraces: []*pbeth.TransactionTrace{
+			balanceChangeTrace(userAddr, pbeth.BalanceChange_REASON_TRANSFER),
+			balanceChangeTrace(botAddr, pbeth.BalanceChange_REASON_TRANSFER),
+		},
+		BalanceChanges: []*pbeth.BalanceChange{
+			{Address: botAddr, Reason: pbeth.BalanceChange_REASON_REWARD_MINE_BLOCK},
+			{Address: botAddr, Reason: pbeth.BalanceChange_REASON_WITHDRAWAL},
+		},
+	}
+
+	out, err := f.Transform(testBlock(t, block), transform.NewNilObj())
+	require.NoError(t, err)
+
+	filtered := out.(*pbeth.Block)
+	require.Len(t, filtered.TransactionTraces, 1)
+	assert.Equal(t, userAddr.Bytes(), filtered.TransactionTraces[0].Calls[0].BalanceChanges[0].Address)
+	require.Len(t, filtered.BalanceChanges, 1)
+	assert.Equal(t, pbeth.BalanceChange_REASON_WITHDRAWAL, filtered.BalanceChanges[0].Reason)
+}
@@ -0,0 +1,370 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// CombinedIndexFile is a `combined` index file, as written by EthCombinedIndexer, covering
+// the blocks [LowBlockNum, LowBlockNum + Size)
+type CombinedIndexFile struct {
+	Filename    string
+	LowBlockNum uint64
+	Size        uint64
+}
+
+func (f *CombinedIndexFile) ExclusiveHighBlockNum() uint64 {
+	return f.LowBlockNum + f.Size
+}
+
+// parseCombinedIndexFilename parses the filenames of the form `0000010000.1000.combined.idx`,
+// ok is false for files that are not a `combined` index
+func parseCombinedIndexFilename(filename string) (file *CombinedIndexFile, ok bool) {
+	parts := strings.Split(filename, ".")
+	if len(parts) != 4 || parts[2] != CombinedIndexerShortName || parts[3] != "idx" {
+		return nil, false
+	}
+
+	lowBlockNum, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	size, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || size == 0 {
+		return nil, false
+	}
+
+	return &CombinedIndexFile{Filename: filename, LowBlockNum: lowBlockNum, Size: size}, true
+}
+
+// defaultPossibleIndexSizes are the index sizes looked for when none are configured, largest
+// first, the same as the block index provider's default
+var defaultPossibleIndexSizes = []uint64{100000, 10000, 1000, 100}
+
+// ListCombinedIndexFiles lists the `combined` index files of the store covering the blocks
+// [startBlock, stopBlock). When index files of different sizes overlap, the largest ones are
+// preferred like the index provider of the CombinedFilter does, so that no block is covered twice.
+//
+// The store is walked from the boundary of the largest of the possible index sizes at or below
+// startBlock, the default ones being used when possibleIndexSizes is empty, so an index file of a
+// larger size starting before that boundary is not listed.
+func ListCombinedIndexFiles(ctx context.Context, store dstore.Store, startBlock, stopBlock uint64, possibleIndexSizes []uint64) (out []*CombinedIndexFile, err error) {
+	if len(possibleIndexSizes) == 0 {
+		possibleIndexSizes = defaultPossibleIndexSizes
+	}
+
+	largestSize := slices.Max(possibleIndexSizes)
+	if largestSize == 0 {
+		return nil, fmt.Errorf("index sizes must be greater than 0")
+	}
+	startingPoint := fmt.Sprintf("%010d", startBlock-startBlock%largestSize)
+
+	var files []*CombinedIndexFile
+	err = store.WalkFrom(ctx, "", startingPoint, func(filename string) error {
+		file, ok := parseCombinedIndexFilename(filename)
+		if !ok {
+			return nil
+		}
+
+		if file.LowBlockNum >= stopBlock {
+			return dstore.StopIteration
+		}
+
+		if file.ExclusiveHighBlockNum() > startBlock {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, dstore.StopIteration) {
+		return nil, fmt.Errorf("walking index store: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].LowBlockNum == files[j].LowBlockNum {
+			return files[i].Size > files[j].Size
+		}
+		return files[i].LowBlockNum < files[j].LowBlockNum
+	})
+
+	var covered uint64
+	for _, file := range files {
+		if len(out) != 0 && file.LowBlockNum < covered {
+			continue
+		}
+		out = append(out, file)
+		covered = file.ExclusiveHighBlockNum()
+	}
+	return out, nil
+}
+
+// CombinedIndexBundle is the content of a `combined` index file, the bitmap of each key
+// holding the block numbers in which the key was seen
+type CombinedIndexBundle struct {
+	*CombinedIndexFile
+	Bitmaps map[string]*roaring64.Bitmap
+
+	// positions is true for a `combinedtrx` bundle whose bitmaps hold transaction positions
+	positions bool
+}
+
+// ReadCombinedIndexBundle reads and decodes the index file from the store
+func ReadCombinedIndexBundle(ctx context.Context, store dstore.Store, file *CombinedIndexFile) (*CombinedIndexBundle, error) {
+	bitmaps, err := readIndexBitmaps(ctx, store, file.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CombinedIndexBundle{CombinedIndexFile: file, Bitmaps: bitmaps}, nil
+}
+
+// readIndexBitmaps reads the bitmap of each key of an index file written by a BlockIndexer, or
+// with the same format
+func readIndexBitmaps(ctx context.Context, store dstore.Store, filename string) (map[string]*roaring64.Bitmap, error) {
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("open index %q: %w", filename, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read index %q: %w", filename, err)
+	}
+
+	pbIndex := &pbbstream.GenericBlockIndex{}
+	if err := proto.Unmarshal(content, pbIndex); err != nil {
+		return nil, fmt.Errorf("unmarshal index %q: %w", filename, err)
+	}
+
+	out := make(map[string]*roaring64.Bitmap, len(pbIndex.Kv))
+	for _, kv := range pbIndex.Kv {
+		bitmap := roaring64.NewBitmap()
+		if err := bitmap.UnmarshalBinary(kv.Bitmap); err != nil {
+			return nil, fmt.Errorf("unmarshal index %q bitmap of key %q: %w", filename, string(kv.Key), err)
+		}
+		out[string(kv.Key)] = bitmap
+	}
+	return out, nil
+}
+
+// NewCombinedIndexBundle returns an empty bundle for the file, to be filled by an EthCombinedIndexer
+// using the bundle as its BlockIndexer, e.g. to rebuild an index file from the merged blocks
+func NewCombinedIndexBundle(file *CombinedIndexFile) *CombinedIndexBundle {
+	return &CombinedIndexBundle{
+		CombinedIndexFile: file,
+		Bitmaps:           make(map[string]*roaring64.Bitmap),
+	}
+}
+
+// Add implements Indexer, value being a block number, or a TransactionPosition for a bundle
+// created by NewTransactionPositionsBundle. Blocks outside of the bundle's range are ignored.
+func (b *CombinedIndexBundle) Add(keys []string, value uint64) {
+	blockNum := value
+	if b.positions {
+		blockNum = value >> transactionIndexBits
+	}
+	if blockNum < b.LowBlockNum || blockNum >= b.ExclusiveHighBlockNum() {
+		return
+	}
+
+	for _, key := range keys {
+		bitmap := b.Bitmaps[key]
+		if bitmap == nil {
+			bitmap = roaring64.NewBitmap()
+			b.Bitmaps[key] = bitmap
+		}
+		bitmap.Add(value)
+	}
+}
+
+// Write writes the bundle to the store under its filename, in the same format as the BlockIndexer
+func (b *CombinedIndexBundle) Write(ctx context.Context, store dstore.Store) error {
+	pbIndex := &pbbstream.GenericBlockIndex{}
+	for key, bitmap := range b.Bitmaps {
+		content, err := bitmap.ToBytes()
+		if err != nil {
+			return fmt.Errorf("marshal bitmap of key %q: %w", key, err)
+		}
+		pbIndex.Kv = append(pbIndex.Kv, &pbbstream.KeyToBitmap{Key: []byte(key), Bitmap: content})
+	}
+
+	content, err := proto.Marshal(pbIndex)
+	if err != nil {
+		return fmt.Errorf("marshal index %q: %w", b.Filename, err)
+	}
+
+	if err := store.WriteObject(ctx, b.Filename, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("write index %q: %w", b.Filename, err)
+	}
+	return nil
+}
+
+// CombinedIndexKeyDiff is a key whose bitmap differs between the expected and the actual bundle
+type CombinedIndexKeyDiff struct {
+	Key string
+
+	// MissingBlocks are the blocks in which the key was expected but that are absent from the
+	// actual bitmap, those blocks are wrongly skipped by the filters on that key
+	MissingBlocks []uint64
+
+	// ExtraBlocks are the blocks present in the actual bitmap but in which the key was not expected,
+	// those blocks are needlessly read by the filters on that key
+	ExtraBlocks []uint64
+}
+
+// Diff compares the bundle, holding the expected bitmaps, to the actual one and returns the keys
+// whose bitmaps differ, sorted by key. Keys absent from one of the bundles are reported with all
+// their blocks missing or extra.
+func (b *CombinedIndexBundle) Diff(actual *CombinedIndexBundle) (out []*CombinedIndexKeyDiff) {
+	empty := roaring64.NewBitmap()
+	bitmapOrEmpty := func(bitmaps map[string]*roaring64.Bitmap, key string) *roaring64.Bitmap {
+		if bitmap := bitmaps[key]; bitmap != nil {
+			return bitmap
+		}
+		return empty
+	}
+
+	keys := make(map[string]bool, len(b.Bitmaps))
+	for key := range b.Bitmaps {
+		keys[key] = true
+	}
+	for key := range actual.Bitmaps {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		expected, found := bitmapOrEmpty(b.Bitmaps, key), bitmapOrEmpty(actual.Bitmaps, key)
+		if expected.Equals(found) {
+			continue
+		}
+
+		out = append(out, &CombinedIndexKeyDiff{
+			Key:           key,
+			MissingBlocks: roaring64.AndNot(expected, found).ToArray(),
+			ExtraBlocks:   roaring64.AndNot(found, expected).ToArray(),
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func (b *CombinedIndexBundle) Get(key string) *roaring64.Bitmap {
+	return b.Bitmaps[key]
+}
+
+func (b *CombinedIndexBundle) GetByPrefixAndSuffix(prefix, suffix string) *roaring64.Bitmap {
+	var matching []*roaring64.Bitmap
+	for key, bitmap := range b.Bitmaps {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+			matching = append(matching, bitmap)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+	return roaring64.FastOr(matching...)
+}
+
+// NewCombinedIndexQuery returns the function selecting, out of an index bundle, the blocks that
+// the index provider of the combined filter would select. Like the index provider, it cannot be
+// used with a filter that has no inclusion filter.
+func NewCombinedIndexQuery(in *pbtransform.CombinedFilter) (func(transform.BitmapGetter) []uint64, error) {
+	f, err := newCombinedFilter(in, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.hasInclusionFilters() {
+		return nil, fmt.Errorf("a combined filter without inclusion filters cannot use the index")
+	}
+
+	return getcombinedFilterFunc(f), nil
+}
+
+// combinedIndexKeyKinds lists the prefixes of the combined index, longest first since some
+// prefixes are the beginning of others, rawValue is set for the values that are not hex encoded
+var combinedIndexKeyKinds = []struct {
+	prefix   string
+	kind     func(value string) string
+	rawValue bool
+}{
+	{IdxPrefixLogTopic1, fixedKind("log topic1"), false},
+	{IdxPrefixLogTopic2, fixedKind("log topic2"), false},
+	{IdxPrefixLogTopic3, fixedKind("log topic3"), false},
+	{IdxPrefixTransactionFrom, fixedKind("transaction from"), false},
+	{IdxPrefixBalanceChangeReason, fixedKind("balance change reason"), true},
+	{IdxPrefixStorageChangeKey, fixedKind("storage change slot"), false},
+	{IdxPrefixContractCreationCodeHash, fixedKind("contract creation code hash"), false},
+	{IdxPrefixValueTransferFrom, fixedKind("value transfer from"), false},
+	{IdxPrefixValueTransferTo, fixedKind("value transfer to"), false},
+	{IdxPrefixValueTransferMagnitude, fixedKind("value transfer magnitude"), true},
+	{IdxPrefixLog, addressOrSignatureKind("log address", "log signature"), false},
+	{IdxPrefixCall, addressOrSignatureKind("call address", "call signature"), false},
+	{IdxPrefixTransaction, addressOrSignatureKind("transaction to", "transaction signature"), false},
+	{IdxPrefixBalanceChange, fixedKind("balance change address"), false},
+	{IdxPrefixStorageChange, fixedKind("storage change address"), false},
+	{IdxPrefixContractCreation, presenceKind("contract creation", "contract creation deployer"), false},
+	{IdxPrefixValueTransfer, presenceKind("value transfer", ""), false},
+}
+
+func fixedKind(kind string) func(string) string {
+	return func(string) string { return kind }
+}
+
+// addressOrSignatureKind tells apart addresses (20 bytes) from signatures (4 bytes for methods
+// and 32 bytes for events) sharing the same prefix
+func addressOrSignatureKind(address, signature string) func(string) string {
+	return func(value string) string {
+		if len(value) == 40 {
+			return address
+		}
+		return signature
+	}
+}
+
+// presenceKind tells apart the key without value, marking blocks where something happened,
+// from the keys having one
+func presenceKind(presence, withValue string) func(string) string {
+	return func(value string) string {
+		if value == "" {
+			return presence
+		}
+		return withValue
+	}
+}
+
+// CombinedIndexKeyKind returns a human readable description of what a key of the combined index
+// refers to (e.g. 'call address' or 'log signature') along with the value indexed in it, prefixed
+// with '0x' when hex encoded. The kind is empty when the key is not known.
+func CombinedIndexKeyKind(key string) (kind string, value string) {
+	for _, k := range combinedIndexKeyKinds {
+		value, found := strings.CutPrefix(key, k.prefix)
+		if !found {
+			continue
+		}
+
+		if kind := k.kind(value); kind != "" {
+			if value != "" && !k.rawValue {
+				value = "0x" + value
+			}
+			return kind, value
+		}
+	}
+	return "", key
+}
@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func setTestIndexFile(t *testing.T, store *dstore.MockStore, filename string, bitmaps testBitmaps) {
+	t.Helper()
+
+	pbIndex := &pbbstream.GenericBlockIndex{}
+	for key, bitmap := range bitmaps {
+		content, err := bitmap.ToBytes()
+		require.NoError(t, err)
+		pbIndex.Kv = append(pbIndex.Kv, &pbbstream.KeyToBitmap{Key: []byte(key), Bitmap: content})
+	}
+
+	content, err := proto.Marshal(pbIndex)
+	require.NoError(t, err)
+	store.SetFile(filename, content)
+}
+
+func TestListCombinedIndexFiles(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	for _, filename := range []string{
+		"0000000000.1000.combined.idx",
+		"0000001000.1000.combined.idx",
+		"0000000000.10000.combined.idx",
+		"0000010000.1000.combined.idx",
+		"0000011000.1000.combined.idx",
+		"0000012000.1000.combined.idx",
+		"0000010000.1000.other.idx",
+	} {
+		store.SetFile(filename, nil)
+	}
+
+	filenames := func(files []*CombinedIndexFile) (out []string) {
+		for _, file := range files {
+			out = append(out, file.Filename)
+		}
+		return out
+	}
+
+	files, err := ListCombinedIndexFiles(context.Background(), store, 500, 12000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"0000000000.10000.combined.idx",
+		"0000010000.1000.combined.idx",
+		"0000011000.1000.combined.idx",
+	}, filenames(files))
+
+	store.SetFile("0000000000.1000000.combined.idx", nil)
+
+	files, err = ListCombinedIndexFiles(context.Background(), store, 200000, 201000, nil)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	files, err = ListCombinedIndexFiles(context.Background(), store, 200000, 201000, []uint64{1000000, 1000})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0000000000.1000000.combined.idx"}, filenames(files))
+}
+
+func TestCombinedIndexQuery(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	setTestIndexFile(t, store, "0000000000.100.combined.idx", testBitmapsFromBlocks(t, map[uint64][]*pbeth.TransactionTrace{
+		10: {transferTrace(walletA, walletB)},
+		11: {transferTrace(walletB, walletA)},
+	}))
+
+	files, err := ListCombinedIndexFiles(context.Background(), store, 0, 100, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	bundle, err := ReadCombinedIndexBundle(context.Background(), store, files[0])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), bundle.ExclusiveHighBlockNum())
+
+	query, err := NewCombinedIndexQuery(&pbtransform.CombinedFilter{
+		LogFilters: []*pbtransform.LogFilter{{Topic1: [][]byte{walletB}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{11}, query(bundle))
+
+	_, err = NewCombinedIndexQuery(&pbtransform.CombinedFilter{
+		ExcludeLogFilters: []*pbtransform.LogFilter{{Topic1: [][]byte{walletB}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestCombinedIndexKeyKind(t *testing.T) {
+	tests := []struct {
+		key           string
+		expectedKind  string
+		expectedValue string
+	}{
+		{IdxPrefixLog + tokenAddr.String(), "log address", "0x" + tokenAddr.String()},
+		{IdxPrefixLog + transferSig.String(), "log signature", "0x" + transferSig.String()},
+		{IdxPrefixLogTopic2 + walletA.String(), "log topic2", "0x" + walletA.String()},
+		{IdxPrefixCall + "a9059cbb", "call signature", "0xa9059cbb"},
+		{IdxPrefixTransactionFrom + tokenAddr.String(), "transaction from", "0x" + tokenAddr.String()},
+		{IdxPrefixBalanceChangeReason + "REWARD_MINE_BLOCK", "balance change reason", "REWARD_MINE_BLOCK"},
+		{IdxPrefixContractCreation, "contract creation", ""},
+		{IdxPrefixContractCreation + tokenAddr.String(), "contract creation deployer", "0x" + tokenAddr.String()},
+		{IdxPrefixValueTransfer, "value transfer", ""},
+		{IdxPrefixValueTransferMagnitude + "64", "value transfer magnitude", "64"},
+		{"Zunknown", "", "Zunknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			kind, value := CombinedIndexKeyKind(test.key)
+			assert.Equal(t, test.expectedKind, kind)
+			assert.Equal(t, test.expectedValue, value)
+		})
+	}
+}
+
+func TestCombinedIndexBundle_RebuildAndDiff(t *testing.T) {
+	file := &CombinedIndexFile{Filename: "0000000000.100.combined.idx", LowBlockNum: 0, Size: 100}
+
+	expected := NewCombinedIndexBundle(file)
+	indexer := &EthCombinedIndexer{BlockIndexer: expected}
+	require.NoError(t, indexer.ProcessBlock(&pbeth.Block{Number: 10, TransactionTraces: []*pbeth.TransactionTrace{transferTrace(walletA, walletB)}}))
+	require.NoError(t, indexer.ProcessBlock(&pbeth.Block{Number: 11, TransactionTraces: []*pbeth.TransactionTrace{transferTrace(walletB, walletA)}}))
+	require.NoError(t, indexer.ProcessBlock(&pbeth.Block{Number: 100, TransactionTraces: []*pbeth.TransactionTrace{transferTrace(walletB, walletB)}}))
+
+	store := dstore.NewMockStore(nil)
+	require.NoError(t, expected.Write(context.Background(), store))
+
+	actual, err := ReadCombinedIndexBundle(context.Background(), store, file)
+	require.NoError(t, err)
+	assert.Empty(t, expected.Diff(actual))
+
+	// Simulates an index built from an older block version, missing the topics and with a spurious block
+	delete(actual.Bitmaps, IdxPrefixLogTopic1+walletA.String())
+	actual.Add([]string{IdxPrefixLog + tokenAddr.String()}, 12)
+
+	assert.Equal(t, []*CombinedIndexKeyDiff{
+		{Key: IdxPrefixLogTopic1 + walletA.String(), MissingBlocks: []uint64{10}, ExtraBlocks: []uint64{}},
+		{Key: IdxPrefixLog + tokenAddr.String(), MissingBlocks: []uint64{}, ExtraBlocks: []uint64{12}},
+	}, expected.Diff(actual))
+}
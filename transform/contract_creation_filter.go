@@ -0,0 +1,85 @@
+package transform
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+type ContractCreationFilter struct {
+	deployers        []eth.Address
+	codeHashPrefixes [][]byte
+}
+
+func (f *ContractCreationFilter) Deployers() []eth.Address {
+	return f.deployers
+}
+
+func (f *ContractCreationFilter) CodeHashPrefixes() [][]byte {
+	return f.codeHashPrefixes
+}
+
+func NewContractCreationFilter(in *pbtransform.ContractCreationFilter) (*ContractCreationFilter, error) {
+	f := &ContractCreationFilter{
+		deployers:        make([]eth.Address, 0, len(in.Deployers)),
+		codeHashPrefixes: make([][]byte, 0, len(in.CodeHashPrefixes)),
+	}
+	for _, addr := range in.Deployers {
+		f.deployers = append(f.deployers, addr)
+	}
+	for _, prefix := range in.CodeHashPrefixes {
+		if len(prefix) == 0 || len(prefix) > 32 {
+			return nil, fmt.Errorf("invalid code hash prefix %x: code hash prefixes must be between 1 and 32 bytes long, got %d", prefix, len(prefix))
+		}
+		f.codeHashPrefixes = append(f.codeHashPrefixes, prefix)
+	}
+
+	return f, nil
+}
+
+func (p *ContractCreationFilter) matchCodeHash(codeHash []byte) bool {
+	if len(p.codeHashPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range p.codeHashPrefixes {
+		if bytes.HasPrefix(codeHash, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ContractCreationFilter) matches(trace *pbeth.TransactionTrace) bool {
+	for _, call := range trace.Calls {
+		if p.matchesCall(call) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ContractCreationFilter) matchesCall(call *pbeth.Call) bool {
+	if !isContractCreation(call) {
+		return false
+	}
+
+	return matchAnyAddress(p.deployers, call.Caller) && p.matchCodeHash(deployedCodeHash(call))
+}
+
+// isContractCreation returns true if the call is a CREATE (or CREATE2) whose state was not reverted
+func isContractCreation(call *pbeth.Call) bool {
+	return call.CallType == pbeth.CallType_CREATE && !call.StateReverted
+}
+
+// deployedCodeHash returns the hash of the code deployed by the CREATE call, nil if none was recorded
+func deployedCodeHash(call *pbeth.Call) []byte {
+	for _, change := range call.CodeChanges {
+		if bytes.Equal(change.Address, call.Address) {
+			return change.NewHash
+		}
+	}
+	return nil
+}
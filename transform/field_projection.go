@@ -0,0 +1,200 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var FieldProjectionMessageName = proto.MessageName(&pbtransform.FieldProjection{})
+
+func NewFieldProjectionTransformFactory(_ dstore.Store, _ []uint64) (*transform.Factory, error) {
+	return FieldProjectionTransformFactory, nil
+}
+
+var FieldProjectionTransformFactory = &transform.Factory{
+	Obj: &pbtransform.FieldProjection{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != FieldProjectionMessageName {
+			return nil, fmt.Errorf("expected type url %q, received %q ", FieldProjectionMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.FieldProjection{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+
+		return NewFieldProjection(filter)
+	},
+}
+
+var blockDescriptor = (&pbeth.Block{}).ProtoReflect().Descriptor()
+
+// fieldPath is a resolved drop path, each element being the field to traverse
+// in the message resolved by the previous element, starting from pbeth.Block
+type fieldPath []protoreflect.FieldDescriptor
+
+func (p fieldPath) String() string {
+	names := make([]string, len(p))
+	for i, field := range p {
+		names[i] = string(field.Name())
+	}
+	return strings.Join(names, ".")
+}
+
+type FieldProjection struct {
+	dropPaths          []fieldPath
+	dropDeepCallInputs bool
+}
+
+func NewFieldProjection(in *pbtransform.FieldProjection) (*FieldProjection, error) {
+	paths := append([]string(nil), in.DropPaths...)
+	if in.DropKeccakPreimages {
+		paths = append(paths, "transaction_traces.calls.keccak_preimages", "system_calls.keccak_preimages")
+	}
+	if in.DropGasChanges {
+		paths = append(paths, "transaction_traces.calls.gas_changes", "system_calls.gas_changes")
+	}
+	if in.DropStorageChanges {
+		paths = append(paths, "transaction_traces.calls.storage_changes", "system_calls.storage_changes")
+	}
+
+	if len(paths) == 0 && !in.DropDeepCallInputs {
+		return nil, fmt.Errorf("a field projection transform requires at-least one drop path or one drop toggle enabled")
+	}
+
+	p := &FieldProjection{
+		dropDeepCallInputs: in.DropDeepCallInputs,
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		fieldPath, err := parseFieldPath(path)
+		if err != nil {
+			return nil, err
+		}
+		p.dropPaths = append(p.dropPaths, fieldPath)
+	}
+
+	return p, nil
+}
+
+// newKeepOnlyProjection returns a projection dropping all top-level fields of the block
+// except the ones provided
+func newKeepOnlyProjection(keepFields ...protoreflect.Name) *FieldProjection {
+	keep := make(map[protoreflect.Name]bool, len(keepFields))
+	for _, name := range keepFields {
+		keep[name] = true
+	}
+
+	p := &FieldProjection{}
+	fields := blockDescriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if field := fields.Get(i); !keep[field.Name()] {
+			p.dropPaths = append(p.dropPaths, fieldPath{field})
+		}
+	}
+	return p
+}
+
+func parseFieldPath(path string) (out fieldPath, err error) {
+	names := strings.Split(path, ".")
+
+	desc := blockDescriptor
+	for i, name := range names {
+		if desc == nil {
+			return nil, fmt.Errorf("invalid drop path %q: field %q is not a message", path, names[i-1])
+		}
+
+		field := desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("invalid drop path %q: unknown field %q in message %s", path, name, desc.FullName())
+		}
+
+		if field.IsMap() && i != len(names)-1 {
+			return nil, fmt.Errorf("invalid drop path %q: map field %q can only be the last element of the path", path, name)
+		}
+
+		out = append(out, field)
+		desc = field.Message()
+	}
+
+	return out, nil
+}
+
+func (p *FieldProjection) String() string {
+	paths := make([]string, len(p.dropPaths))
+	for i, path := range p.dropPaths {
+		paths[i] = path.String()
+	}
+	return fmt.Sprintf("Field projection: DropPaths:[%s], DropDeepCallInputs: %v", truncate(strings.Join(paths, ","), 90, "..."), p.dropDeepCallInputs)
+}
+
+func (p *FieldProjection) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := blockFromInput(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	p.apply(ethBlock)
+	return ethBlock, nil
+}
+
+func (p *FieldProjection) apply(block *pbeth.Block) {
+	msg := block.ProtoReflect()
+	for _, path := range p.dropPaths {
+		dropField(msg, path)
+	}
+
+	if p.dropDeepCallInputs {
+		for _, trace := range block.TransactionTraces {
+			dropDeepCallInputs(trace.Calls)
+		}
+		dropDeepCallInputs(block.SystemCalls)
+	}
+}
+
+func dropField(msg protoreflect.Message, path fieldPath) {
+	field := path[0]
+	if len(path) == 1 {
+		msg.Clear(field)
+		return
+	}
+
+	if !msg.Has(field) {
+		return
+	}
+
+	if field.IsList() {
+		list := msg.Get(field).List()
+		for i := 0; i < list.Len(); i++ {
+			dropField(list.Get(i).Message(), path[1:])
+		}
+		return
+	}
+
+	dropField(msg.Get(field).Message(), path[1:])
+}
+
+func dropDeepCallInputs(calls []*pbeth.Call) {
+	for _, call := range calls {
+		if call.Depth > 0 {
+			call.Input = nil
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestFieldProjection_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(FieldProjectionTransformFactory)
+
+	projection, err := anypb.New(&pbtransform.FieldProjection{
+		DropPaths:           []string{"transaction_traces.receipt.logs_bloom", "uncles"},
+		DropKeccakPreimages: true,
+		DropDeepCallInputs:  true,
+	})
+	require.NoError(t, err)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{projection})
+	require.NoError(t, err)
+
+	testBlock := testBlockFromFiles(t, "block.json")
+	block := &pbeth.Block{}
+	require.NoError(t, testBlock.Payload.UnmarshalTo(block))
+
+	output, err := preprocFunc(testBlock)
+	require.NoError(t, err)
+
+	projected := output.(*pbeth.Block)
+	assertProtoEqual(t, block.Header, projected.Header)
+	assert.Nil(t, projected.Uncles)
+	require.Len(t, projected.TransactionTraces, len(block.TransactionTraces))
+
+	for i, trace := range projected.TransactionTraces {
+		assert.Nil(t, trace.Receipt.LogsBloom)
+		assert.Equal(t, block.TransactionTraces[i].Receipt.Logs, trace.Receipt.Logs)
+
+		for j, call := range trace.Calls {
+			original := block.TransactionTraces[i].Calls[j]
+
+			assert.Nil(t, call.KeccakPreimages)
+			assert.Equal(t, original.GasChanges, call.GasChanges)
+			if call.Depth > 0 {
+				assert.Nil(t, call.Input)
+			} else {
+				assert.Equal(t, original.Input, call.Input)
+			}
+		}
+	}
+}
+
+func TestNewFieldProjection_InvalidPaths(t *testing.T) {
+	for _, path := range []string{"unknown", "transaction_traces.unknown", "number.value", "transaction_traces.calls.keccak_preimages.key"} {
+		_, err := NewFieldProjection(&pbtransform.FieldProjection{DropPaths: []string{path}})
+		assert.Error(t, err, path)
+	}
+
+	_, err := NewFieldProjection(&pbtransform.FieldProjection{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+// FilterGroup matches the transactions matched by all of its filters
+type FilterGroup struct {
+	CallToFilters      []*CallToFilter
+	LogFilters         []*LogFilter
+	TransactionFilters []*TransactionFilter
+
+	members []traceFilter
+}
+
+func NewFilterGroup(in *pbtransform.FilterGroup) (*FilterGroup, error) {
+	if len(in.CallFilters) == 0 && len(in.LogFilters) == 0 && len(in.TransactionFilters) == 0 {
+		return nil, fmt.Errorf("a filter group requires at-least one call filter, one log filter or one transaction filter")
+	}
+
+	callToFilters, err := newFilters(in.CallFilters, NewCallToFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	logFilters, err := newFilters(in.LogFilters, NewLogFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionFilters, err := newFilters(in.TransactionFilters, NewTransactionFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &FilterGroup{
+		CallToFilters:      callToFilters,
+		LogFilters:         logFilters,
+		TransactionFilters: transactionFilters,
+	}
+
+	g.members = appendFilters[traceFilter](nil, g.CallToFilters)
+	g.members = appendFilters(g.members, g.LogFilters)
+	g.members = appendFilters(g.members, g.TransactionFilters)
+
+	return g, nil
+}
+
+func (g *FilterGroup) matches(trace *pbeth.TransactionTrace) bool {
+	return matchesAll(trace, false, g.members)
+}
+
+func (g *FilterGroup) matchesBase(trace *pbeth.TransactionTrace) bool {
+	return matchesAll(trace, true, g.members)
+}
+
+func matchesAll(trace *pbeth.TransactionTrace, base bool, filters []traceFilter) bool {
+	for _, f := range filters {
+		if !matchesFilter(trace, base, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterGroupString(in *FilterGroup, limit int) string {
+	sections := []string{
+		fmt.Sprintf("calls:[%s]", filtersString(in.CallToFilters, limit, callToFilterString)),
+		fmt.Sprintf("logs:[%s]", filtersString(in.LogFilters, limit, logFilterString)),
+	}
+	if len(in.TransactionFilters) != 0 {
+		sections = append(sections, fmt.Sprintf("transactions:[%s]", filtersString(in.TransactionFilters, limit, transactionFilterString)))
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(sections, " AND "))
+}
+
+// filterGroupBitmap intersects the bitmaps of all the filters of the group
+func filterGroupBitmap(g *FilterGroup, bitmaps transform.BitmapGetter) *roaring64.Bitmap {
+	var out *roaring64.Bitmap
+	and := func(bm *roaring64.Bitmap) {
+		if out == nil {
+			out = bm
+			return
+		}
+		out.And(bm)
+	}
+
+	for _, f := range g.CallToFilters {
+		and(filterBitmap(f, bitmaps, IdxPrefixCall))
+	}
+	for _, f := range g.LogFilters {
+		and(logFilterBitmap(f, bitmaps))
+	}
+	for _, f := range g.TransactionFilters {
+		and(transactionFilterBitmap(f, bitmaps))
+	}
+
+	return out
+}
@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	poolAddr     = eth.MustNewAddress("0x4444444444444444444444444444444444444444")
+	swapEventSig = eth.MustNewHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822")
+)
+
+// swapTrace is a transaction from userAddr calling 'to', emitting a Swap event from 'pool' when set
+func swapTrace(to eth.Address, pool eth.Address) *pbeth.TransactionTrace {
+	trace := transactionTrace(userAddr, to, nil)
+	trace.Calls = []*pbeth.Call{{Index: 1, Caller: userAddr, Address: to}}
+	if pool != nil {
+		log := &pbeth.Log{Address: pool, Topics: [][]byte{swapEventSig}}
+		trace.Calls = append(trace.Calls, &pbeth.Call{Index: 2, ParentIndex: 1, Depth: 1, Caller: to, Address: pool, Logs: []*pbeth.Log{log}})
+		trace.Receipt.Logs = []*pbeth.Log{log}
+	}
+	return trace
+}
+
+var routerAndSwapGroup = &pbtransform.FilterGroup{
+	CallFilters: []*pbtransform.CallToFilter{{Addresses: [][]byte{routerAddr}}},
+	LogFilters:  []*pbtransform.LogFilter{{Addresses: [][]byte{poolAddr}, EventSignatures: [][]byte{swapEventSig}}},
+}
+
+func TestFilterGroup_Matches(t *testing.T) {
+	g, err := NewFilterGroup(routerAndSwapGroup)
+	require.NoError(t, err)
+
+	assert.True(t, g.matches(swapTrace(routerAddr, poolAddr)))
+	assert.False(t, g.matches(swapTrace(routerAddr, nil)))
+	assert.False(t, g.matches(swapTrace(botAddr, poolAddr)))
+
+	_, err = NewFilterGroup(&pbtransform.FilterGroup{})
+	assert.Error(t, err)
+}
+
+func TestFilterGroup_Index(t *testing.T) {
+	bitmaps := testBitmapsFromBlocks(t, map[uint64][]*pbeth.TransactionTrace{
+		10: {swapTrace(routerAddr, poolAddr)},
+		11: {swapTrace(routerAddr, nil)},
+		12: {swapTrace(botAddr, poolAddr)},
+		13: {swapTrace(routerAddr, nil), swapTrace(botAddr, poolAddr)},
+	})
+
+	filter := &pbtransform.CombinedFilter{FilterGroups: []*pbtransform.FilterGroup{routerAndSwapGroup}}
+
+	// The index works at the block level, block 13 is a false positive removed by the transform
+	assert.Equal(t, []uint64{10, 13}, testIndexMatches(t, bitmaps, filter))
+
+	c, err := newCombinedFilter(filter, nil, nil)
+	require.NoError(t, err)
+
+	block := &pbeth.Block{Number: 13, Header: &pbeth.BlockHeader{}, TransactionTraces: []*pbeth.TransactionTrace{swapTrace(routerAddr, nil), swapTrace(botAddr, poolAddr)}}
+	output, err := c.Transform(testBlock(t, block), nil)
+	require.NoError(t, err)
+	assert.Empty(t, output.(*pbeth.Block).TransactionTraces)
+}
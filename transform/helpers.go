@@ -2,6 +2,11 @@ package transform
 
 import (
 	"fmt"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"google.golang.org/protobuf/proto"
 )
 
 func lowBoundary(i uint64, mod uint64) uint64 {
@@ -11,3 +16,21 @@ func lowBoundary(i uint64, mod uint64) uint64 {
 func toIndexFilename(bundleSize, baseBlockNum uint64, shortname string) string {
 	return fmt.Sprintf("%010d.%d.%s.idx", baseBlockNum, bundleSize, shortname)
 }
+
+// blockFromInput returns the block produced by the previous transform when there is one
+// so that transforms can be chained, otherwise it decodes the read-only block's payload
+func blockFromInput(readOnlyBlk *pbbstream.Block, in transform.Input) (*pbeth.Block, error) {
+	if in != nil && in.Obj() != nil {
+		ethBlock, ok := in.Obj().(*pbeth.Block)
+		if !ok {
+			return nil, fmt.Errorf("previous transform produced a %s, expected a block, transforms not producing blocks must be last", proto.MessageName(in.Obj()))
+		}
+		return ethBlock, nil
+	}
+
+	ethBlock := &pbeth.Block{}
+	if err := readOnlyBlk.Payload.UnmarshalTo(ethBlock); err != nil {
+		return nil, fmt.Errorf("mashalling block: %w", err)
+	}
+	return ethBlock, nil
+}
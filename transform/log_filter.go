@@ -17,7 +17,12 @@ var MultiLogFilterMessageName = proto.MessageName(&pbtransform.MultiLogFilter{})
 
 type LogFilter struct {
 	addresses       []eth.Address
+	addressSet      addressSet
 	eventSignatures []eth.Hash
+
+	// topics holds the constraints on the indexed arguments, topics[0] is for `topic.1`,
+	// topics[1] for `topic.2` and topics[2] for `topic.3`
+	topics [3][]eth.Hash
 }
 
 func (f *LogFilter) Addresses() []eth.Address {
@@ -28,9 +33,19 @@ func (f *LogFilter) Signatures() []eth.Hash {
 	return f.eventSignatures
 }
 
+// Topics returns the constraints on the indexed argument at the given position,
+// which must be 1, 2 or 3 (position 0 being the event signature).
+func (f *LogFilter) Topics(position int) []eth.Hash {
+	return f.topics[position-1]
+}
+
+func (f *LogFilter) hasTopics() bool {
+	return len(f.topics[0]) != 0 || len(f.topics[1]) != 0 || len(f.topics[2]) != 0
+}
+
 func NewLogFilter(in *pbtransform.LogFilter) (*LogFilter, error) {
-	if len(in.Addresses) == 0 && len(in.EventSignatures) == 0 {
-		return nil, fmt.Errorf("a log filter transform requires at-least one address or one event signature")
+	if len(in.Addresses) == 0 && in.AddressesUrl == "" && len(in.EventSignatures) == 0 && len(in.Topic1) == 0 && len(in.Topic2) == 0 && len(in.Topic3) == 0 {
+		return nil, fmt.Errorf("a log filter transform requires at-least one address, one event signature or one indexed topic")
 	}
 
 	f := &LogFilter{
@@ -43,6 +58,21 @@ func NewLogFilter(in *pbtransform.LogFilter) (*LogFilter, error) {
 	for i, sig := range in.EventSignatures {
 		f.eventSignatures[i] = sig
 	}
+
+	var err error
+	if f.addresses, err = withAddressList(f.addresses, in.AddressesUrl); err != nil {
+		return nil, err
+	}
+	f.addressSet = newAddressSet(f.addresses)
+
+	for i, topics := range [][][]byte{in.Topic1, in.Topic2, in.Topic3} {
+		for _, topic := range topics {
+			if len(topic) != 32 {
+				return nil, fmt.Errorf("invalid topic%d %x: indexed topics must be exactly 32 bytes long, got %d", i+1, topic, len(topic))
+			}
+			f.topics[i] = append(f.topics[i], topic)
+		}
+	}
 	return f, nil
 }
 
@@ -50,12 +80,7 @@ func (p *LogFilter) matchAddress(src eth.Address) bool {
 	if len(p.addresses) == 0 {
 		return true
 	}
-	for _, addr := range p.addresses {
-		if bytes.Equal(addr, src) {
-			return true
-		}
-	}
-	return false
+	return p.addressSet.contains(src)
 }
 
 func (p *LogFilter) matchEventSignature(topics [][]byte) bool {
@@ -73,9 +98,48 @@ func (p *LogFilter) matchEventSignature(topics [][]byte) bool {
 	return false
 }
 
+// matchTopics checks the indexed arguments (topic.1 to topic.3) of the log against the
+// filter's constraints, a log without the constrained topic never matches.
+func (p *LogFilter) matchTopics(topics [][]byte) bool {
+	for i, wanted := range p.topics {
+		if len(wanted) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 {
+			return false
+		}
+
+		found := false
+		for _, topic := range wanted {
+			if bytes.Equal(topic, topics[i+1]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *LogFilter) matchLog(log *pbeth.Log) bool {
+	return p.matchAddress(log.Address) && p.matchEventSignature(log.Topics) && p.matchTopics(log.Topics)
+}
+
 func (p *LogFilter) matches(trace *pbeth.TransactionTrace) bool {
 	for _, log := range trace.Receipt.Logs {
-		if p.matchAddress(log.Address) && p.matchEventSignature(log.Topics) {
+		if p.matchLog(log) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCall returns true if one of the logs emitted by the call matches
+func (p *LogFilter) matchesCall(call *pbeth.Call) bool {
+	for _, log := range call.Logs {
+		if p.matchLog(log) {
 			return true
 		}
 	}
@@ -101,7 +165,7 @@ func MultiLogFilterTransformFactory(indexStore dstore.Store, possibleIndexSizes
 			if err != nil {
 				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
 			}
-			return newCombinedFilter(nil, filter.LogFilters, indexStore, possibleIndexSizes, false)
+			return newCombinedFilter(&pbtransform.CombinedFilter{LogFilters: filter.LogFilters}, indexStore, possibleIndexSizes)
 		},
 	}
 }
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	transferSig = eth.MustNewHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	walletA     = eth.MustNewHash("0x000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	walletB     = eth.MustNewHash("0x000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	tokenAddr   = eth.MustNewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+)
+
+func transferTrace(from, to eth.Hash) *pbeth.TransactionTrace {
+	return &pbeth.TransactionTrace{
+		Receipt: &pbeth.TransactionReceipt{
+			Logs: []*pbeth.Log{
+				{Address: tokenAddr, Topics: [][]byte{transferSig, from, to}},
+			},
+		},
+	}
+}
+
+func TestLogFilter_MatchesTopics(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *pbtransform.LogFilter
+		trace    *pbeth.TransactionTrace
+		expected bool
+	}{
+		{"topic2 only matching", &pbtransform.LogFilter{Topic2: [][]byte{walletB}}, transferTrace(walletA, walletB), true},
+		{"topic2 only not matching", &pbtransform.LogFilter{Topic2: [][]byte{walletA}}, transferTrace(walletA, walletB), false},
+		{"signature and topic1", &pbtransform.LogFilter{EventSignatures: [][]byte{transferSig}, Topic1: [][]byte{walletB, walletA}}, transferTrace(walletA, walletB), true},
+		{"address and topic1 not matching", &pbtransform.LogFilter{Addresses: [][]byte{tokenAddr}, Topic1: [][]byte{walletB}}, transferTrace(walletA, walletB), false},
+		{"topic3 absent from log", &pbtransform.LogFilter{Topic3: [][]byte{walletA}}, transferTrace(walletA, walletB), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewLogFilter(test.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, f.matches(test.trace))
+		})
+	}
+}
+
+func TestLogFilter_InvalidTopicLength(t *testing.T) {
+	_, err := NewLogFilter(&pbtransform.LogFilter{Topic1: [][]byte{tokenAddr}})
+	require.Error(t, err)
+}
+
+type testBitmaps map[string]*roaring64.Bitmap
+
+func (b testBitmaps) Get(key string) *roaring64.Bitmap {
+	return b[key]
+}
+
+func (b testBitmaps) GetByPrefixAndSuffix(prefix string, suffix string) *roaring64.Bitmap {
+	var out *roaring64.Bitmap
+	for key, bm := range b {
+		if len(key) < len(prefix)+len(suffix) || !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		if out == nil {
+			out = roaring64.NewBitmap()
+		}
+		out.Or(bm)
+	}
+	return out
+}
+
+func (b testBitmaps) Add(keys []string, blockNum uint64) {
+	for _, key := range keys {
+		if b[key] == nil {
+			b[key] = roaring64.NewBitmap()
+		}
+		b[key].Add(blockNum)
+	}
+}
+
+// testBitmapsFromBlocks runs the combined indexer over blocks made of the given traces
+func testBitmapsFromBlocks(t *testing.T, blocks map[uint64][]*pbeth.TransactionTrace) testBitmaps {
+	out := testBitmaps{}
+	indexer := &EthCombinedIndexer{BlockIndexer: out}
+	for num, traces := range blocks {
+		require.NoError(t, indexer.ProcessBlock(&pbeth.Block{Number: num, TransactionTraces: traces}))
+	}
+	return out
+}
+
+// testIndexMatches returns the blocks of the bitmaps matching the combined filter
+func testIndexMatches(t *testing.T, bitmaps testBitmaps, in *pbtransform.CombinedFilter) []uint64 {
+	f, err := newCombinedFilter(in, nil, nil)
+	require.NoError(t, err)
+
+	return getcombinedFilterFunc(f)(bitmaps)
+}
+
+func TestLogFilter_IndexTopics(t *testing.T) {
+	bitmaps := testBitmapsFromBlocks(t, map[uint64][]*pbeth.TransactionTrace{
+		10: {transferTrace(walletA, walletB)},
+		11: {transferTrace(walletB, walletA)},
+		12: {transferTrace(walletB, walletB)},
+	})
+
+	assert.Equal(t, []uint64{11}, testIndexMatches(t, bitmaps, &pbtransform.CombinedFilter{
+		LogFilters: []*pbtransform.LogFilter{{Addresses: [][]byte{tokenAddr}, Topic2: [][]byte{walletA}}},
+	}))
+
+	assert.Equal(t, []uint64{11, 12}, testIndexMatches(t, bitmaps, &pbtransform.CombinedFilter{
+		LogFilters: []*pbtransform.LogFilter{{Topic1: [][]byte{walletB}}},
+	}))
+}
@@ -0,0 +1,133 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var LogsOnlyMessageName = proto.MessageName(&pbtransform.LogsOnly{})
+
+func NewLogsOnlyTransformFactory(_ dstore.Store, _ []uint64) (*transform.Factory, error) {
+	return LogsOnlyTransformFactory, nil
+}
+
+var LogsOnlyTransformFactory = &transform.Factory{
+	Obj: &pbtransform.LogsOnly{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != LogsOnlyMessageName {
+			return nil, fmt.Errorf("expected type url %q, received %q ", LogsOnlyMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.LogsOnly{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+
+		return NewLogsOnly(filter)
+	},
+}
+
+type LogsOnly struct {
+	LogFilters []*LogFilter
+
+	includeRevertedLogs bool
+}
+
+func NewLogsOnly(in *pbtransform.LogsOnly) (*LogsOnly, error) {
+	logFilters, err := newFilters(in.LogFilters, NewLogFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogsOnly{
+		LogFilters:          logFilters,
+		includeRevertedLogs: in.IncludeRevertedLogs,
+	}, nil
+}
+
+func (p *LogsOnly) String() string {
+	limit := 5
+	logs := make([]string, len(p.LogFilters))
+	for i, f := range p.LogFilters {
+		logs[i] = logFilterString(f, limit)
+	}
+
+	return fmt.Sprintf("Logs only: Logs:[%s], IncludeRevertedLogs: %v", truncate(strings.Join(logs, ","), 90, "...}"), p.includeRevertedLogs)
+}
+
+func (p *LogsOnly) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := blockFromInput(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.logs(ethBlock), nil
+}
+
+func (p *LogsOnly) logs(block *pbeth.Block) *pbtransform.Logs {
+	blockRef := &pbeth.BlockRef{
+		Hash:   block.Hash,
+		Number: block.Number,
+	}
+
+	out := &pbtransform.Logs{}
+	add := func(trace *pbeth.TransactionTrace, log *pbeth.Log, reverted bool) {
+		if len(p.LogFilters) != 0 && !p.matchesLog(log) {
+			return
+		}
+
+		out.Logs = append(out.Logs, &pbtransform.LogWithRefs{
+			Log:              log,
+			Reverted:         reverted,
+			TransactionHash:  trace.Hash,
+			TransactionIndex: trace.Index,
+			BlockRef:         blockRef,
+		})
+	}
+
+	for _, trace := range block.TransactionTraces {
+		// Calls are not available on BASE detail level blocks, the receipt holds the non-reverted logs
+		if len(trace.Calls) == 0 {
+			if trace.Receipt != nil {
+				for _, log := range trace.Receipt.Logs {
+					add(trace, log, false)
+				}
+			}
+			continue
+		}
+
+		for _, call := range trace.Calls {
+			if call.StateReverted && !p.includeRevertedLogs {
+				continue
+			}
+			for _, log := range call.Logs {
+				add(trace, log, call.StateReverted)
+			}
+		}
+	}
+
+	sort.SliceStable(out.Logs, func(i, j int) bool {
+		return out.Logs[i].Log.Ordinal < out.Logs[j].Log.Ordinal
+	})
+	return out
+}
+
+func (p *LogsOnly) matchesLog(log *pbeth.Log) bool {
+	for _, f := range p.LogFilters {
+		if f.matchLog(log) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestLogsOnly_Transform(t *testing.T) {
+	testBlock := testBlockFromFiles(t, "block.json")
+	block := &pbeth.Block{}
+	require.NoError(t, testBlock.Payload.UnmarshalTo(block))
+
+	var receiptLogs, revertedLogs, transferLogs int
+	for _, trace := range block.TransactionTraces {
+		receiptLogs += len(trace.Receipt.Logs)
+		for _, log := range trace.Receipt.Logs {
+			if len(log.Topics) > 0 && bytes.Equal(log.Topics[0], transferSig) {
+				transferLogs++
+			}
+		}
+		for _, call := range trace.Calls {
+			if call.StateReverted {
+				revertedLogs += len(call.Logs)
+			}
+		}
+	}
+	require.NotZero(t, revertedLogs, "test block is expected to hold reverted logs")
+
+	tests := []struct {
+		name     string
+		logsOnly *pbtransform.LogsOnly
+		expected int
+	}{
+		{"reverted excluded by default", &pbtransform.LogsOnly{}, receiptLogs},
+		{"reverted included", &pbtransform.LogsOnly{IncludeRevertedLogs: true}, receiptLogs + revertedLogs},
+		{"log filters", &pbtransform.LogsOnly{LogFilters: []*pbtransform.LogFilter{{EventSignatures: [][]byte{transferSig}}}}, transferLogs},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transformReg := transform.NewRegistry()
+			transformReg.Register(LogsOnlyTransformFactory)
+
+			logsOnly, err := anypb.New(test.logsOnly)
+			require.NoError(t, err)
+
+			preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{logsOnly})
+			require.NoError(t, err)
+
+			output, err := preprocFunc(testBlock)
+			require.NoError(t, err)
+
+			logs := output.(*pbtransform.Logs).Logs
+			require.Len(t, logs, test.expected)
+
+			for i, log := range logs {
+				assert.Equal(t, block.Number, log.BlockRef.Number)
+				assert.NotEmpty(t, log.TransactionHash)
+				if i > 0 {
+					assert.Less(t, logs[i-1].Log.Ordinal, log.Log.Ordinal)
+				}
+				if !test.logsOnly.IncludeRevertedLogs {
+					assert.False(t, log.Reverted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package transform
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+type StorageChangeFilter struct {
+	addresses []eth.Address
+	keys      []eth.Hash
+}
+
+func (f *StorageChangeFilter) Addresses() []eth.Address {
+	return f.addresses
+}
+
+func (f *StorageChangeFilter) Keys() []eth.Hash {
+	return f.keys
+}
+
+func NewStorageChangeFilter(in *pbtransform.StorageChangeFilter) (*StorageChangeFilter, error) {
+	if len(in.Addresses) == 0 {
+		return nil, fmt.Errorf("a storage change filter transform requires at-least one contract address")
+	}
+
+	f := &StorageChangeFilter{
+		addresses: make([]eth.Address, 0, len(in.Addresses)),
+		keys:      make([]eth.Hash, 0, len(in.Keys)),
+	}
+	for _, addr := range in.Addresses {
+		f.addresses = append(f.addresses, addr)
+	}
+	for _, key := range in.Keys {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("invalid storage key %x: storage keys must be exactly 32 bytes long, got %d", key, len(key))
+		}
+		f.keys = append(f.keys, key)
+	}
+
+	return f, nil
+}
+
+func (p *StorageChangeFilter) matchKey(key []byte) bool {
+	if len(p.keys) == 0 {
+		return true
+	}
+	for _, k := range p.keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *StorageChangeFilter) matches(trace *pbeth.TransactionTrace) bool {
+	for _, call := range trace.Calls {
+		if p.matchesCall(call) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *StorageChangeFilter) matchesCall(call *pbeth.Call) bool {
+	if call.StateReverted {
+		return false
+	}
+
+	for _, change := range call.StorageChanges {
+		if matchAnyAddress(p.addresses, change.Address) && p.matchKey(change.Key) {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,10 @@ func testBlockFromFiles(t testing.T, filename string) *pbbstream.Block {
 	err = protojson.Unmarshal(file, b)
 	require.NoError(t, err)
 
+	return testBlock(t, b)
+}
+
+func testBlock(t testing.T, b *pbeth.Block) *pbbstream.Block {
 	anyBlock, err := anypb.New(b)
 	require.NoError(t, err)
 
@@ -34,3 +38,18 @@ func testBlockFromFiles(t testing.T, filename string) *pbbstream.Block {
 
 	return blk
 }
+
+// testTrace returns a transaction trace from `from` to `to`, with an empty receipt, made of the
+// given calls indexed in order from 1, the calls following the first one without a parent being
+// its children
+func testTrace(from, to []byte, calls ...*pbeth.Call) *pbeth.TransactionTrace {
+	for i, call := range calls {
+		call.Index = uint32(i + 1)
+		if i > 0 && call.ParentIndex == 0 {
+			call.ParentIndex = 1
+			call.Depth = 1
+		}
+	}
+
+	return &pbeth.TransactionTrace{From: from, To: to, Receipt: &pbeth.TransactionReceipt{}, Calls: calls}
+}
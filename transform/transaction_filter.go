@@ -0,0 +1,81 @@
+package transform
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+type TransactionFilter struct {
+	from       []eth.Address
+	to         []eth.Address
+	signatures []eth.Hash
+}
+
+// Addresses returns the recipient (TO) addresses of the filter
+func (f *TransactionFilter) Addresses() []eth.Address {
+	return f.to
+}
+
+func (f *TransactionFilter) Signatures() []eth.Hash {
+	return f.signatures
+}
+
+// From returns the sender (FROM) addresses of the filter
+func (f *TransactionFilter) From() []eth.Address {
+	return f.from
+}
+
+func NewTransactionFilter(in *pbtransform.TransactionFilter) (*TransactionFilter, error) {
+	if len(in.From) == 0 && len(in.To) == 0 && len(in.Signatures) == 0 {
+		return nil, fmt.Errorf("a transaction filter transform requires at-least one from address, one to address or one method signature")
+	}
+
+	f := &TransactionFilter{
+		from:       make([]eth.Address, 0, len(in.From)),
+		to:         make([]eth.Address, 0, len(in.To)),
+		signatures: make([]eth.Hash, 0, len(in.Signatures)),
+	}
+	for _, addr := range in.From {
+		f.from = append(f.from, addr)
+	}
+	for _, addr := range in.To {
+		f.to = append(f.to, addr)
+	}
+	for _, sig := range in.Signatures {
+		f.signatures = append(f.signatures, sig)
+	}
+
+	return f, nil
+}
+
+func matchAnyAddress(addresses []eth.Address, src eth.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	for _, addr := range addresses {
+		if bytes.Equal(addr, src) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *TransactionFilter) matchSignature(src eth.Hash) bool {
+	if len(p.signatures) == 0 {
+		return true
+	}
+	for _, sig := range p.signatures {
+		if bytes.Equal(sig, src) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *TransactionFilter) matches(trace *pbeth.TransactionTrace) bool {
+	return matchAnyAddress(p.from, trace.From) && matchAnyAddress(p.to, trace.To) && p.matchSignature(trace.Method())
+}
@@ -0,0 +1,61 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	botAddr    = eth.MustNewAddress("0x1111111111111111111111111111111111111111")
+	userAddr   = eth.MustNewAddress("0x2222222222222222222222222222222222222222")
+	routerAddr = eth.MustNewAddress("0x3333333333333333333333333333333333333333")
+	swapSig    = eth.MustNewHex("0x38ed1739")
+)
+
+func transactionTrace(from, to eth.Address, input []byte) *pbeth.TransactionTrace {
+	return &pbeth.TransactionTrace{From: from, To: to, Input: input, Receipt: &pbeth.TransactionReceipt{}}
+}
+
+func TestTransactionFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *pbtransform.TransactionFilter
+		trace    *pbeth.TransactionTrace
+		expected bool
+	}{
+		{"from matching", &pbtransform.TransactionFilter{From: [][]byte{userAddr}}, transactionTrace(userAddr, routerAddr, nil), true},
+		{"from not matching", &pbtransform.TransactionFilter{From: [][]byte{botAddr}}, transactionTrace(userAddr, routerAddr, nil), false},
+		{"to and signature", &pbtransform.TransactionFilter{To: [][]byte{routerAddr}, Signatures: [][]byte{swapSig}}, transactionTrace(userAddr, routerAddr, append(swapSig, 0x01)), true},
+		{"signature without input", &pbtransform.TransactionFilter{Signatures: [][]byte{swapSig}}, transactionTrace(userAddr, routerAddr, nil), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewTransactionFilter(test.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, f.matches(test.trace))
+		})
+	}
+}
+
+func TestTransactionFilter_Index(t *testing.T) {
+	bitmaps := testBitmapsFromBlocks(t, map[uint64][]*pbeth.TransactionTrace{
+		10: {transactionTrace(userAddr, routerAddr, swapSig)},
+		11: {transactionTrace(botAddr, routerAddr, swapSig)},
+		12: {transactionTrace(routerAddr, userAddr, nil)},
+	})
+
+	assert.Equal(t, []uint64{10, 11}, testIndexMatches(t, bitmaps, &pbtransform.CombinedFilter{
+		TransactionFilters: []*pbtransform.TransactionFilter{{From: [][]byte{userAddr, botAddr}, To: [][]byte{routerAddr}}},
+	}))
+
+	assert.Equal(t, []uint64{12}, testIndexMatches(t, bitmaps, &pbtransform.CombinedFilter{
+		TransactionFilters: []*pbtransform.TransactionFilter{{To: [][]byte{userAddr}}},
+	}))
+}
@@ -0,0 +1,217 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+// TransactionPositionsIndexerShortName is the short name of the index files recording, for each
+// key of the combined index, the positions of the transactions in which the key was seen. They
+// are written next to the `combined` ones, with the same keys and ranges.
+const TransactionPositionsIndexerShortName = "combinedtrx"
+
+// transactionIndexBits is the number of low bits of a transaction position holding the index of
+// the transaction within its block, the remaining high bits holding the block number
+const transactionIndexBits = 20
+const maxTransactionsPerBlock = 1 << transactionIndexBits
+
+// transactionPositionsUncoveredKey marks, with the position of their first transaction, the blocks
+// whose transactions are not recorded by the index because they have more than
+// maxTransactionsPerBlock transactions, all their transactions are scanned
+const transactionPositionsUncoveredKey = "!uncovered"
+
+// TransactionPosition packs the block number and the index of the transaction within the block's
+// transaction traces in a single value, ordered like the transactions of the chain, so that the
+// bitmaps of the transaction positions index can be queried like the block ones
+func TransactionPosition(blockNum uint64, index int) uint64 {
+	return blockNum<<transactionIndexBits | uint64(index)
+}
+
+func transactionPositionsFilename(lowBlockNum, indexSize uint64) string {
+	return fmt.Sprintf("%010d.%d.%s.idx", lowBlockNum, indexSize, TransactionPositionsIndexerShortName)
+}
+
+// NewTransactionPositionsBundle returns an empty bundle for the `combinedtrx` index file covering
+// the same blocks as the `combined` one, to be filled by an EthCombinedIndexer using the bundle as
+// its TransactionPositions, e.g. to rebuild the file from the merged blocks
+func NewTransactionPositionsBundle(file *CombinedIndexFile) *CombinedIndexBundle {
+	bundle := NewCombinedIndexBundle(&CombinedIndexFile{
+		Filename:    transactionPositionsFilename(file.LowBlockNum, file.Size),
+		LowBlockNum: file.LowBlockNum,
+		Size:        file.Size,
+	})
+	bundle.positions = true
+	return bundle
+}
+
+// TransactionIndex returns the block number and the index of the transaction within the block
+// packed in the position, see TransactionPosition
+func TransactionIndex(position uint64) (blockNum uint64, index int) {
+	return position >> transactionIndexBits, int(position & (maxTransactionsPerBlock - 1))
+}
+
+// transactionPositionsWriteRetryDelay is the delay before the first retry of a failed index file
+// write, each following retry waiting one more delay
+var transactionPositionsWriteRetryDelay = 2 * time.Second
+
+// transactionPositionsIndexer writes the transaction positions index files, starting and
+// completing its ranges exactly like the BlockIndexer writing the `combined` ones so that a
+// transaction positions index file is only written for a range whose blocks were all seen
+type transactionPositionsIndexer struct {
+	store     dstore.Store
+	indexSize uint64
+	current   *CombinedIndexBundle
+}
+
+func newTransactionPositionsIndexer(store dstore.Store, indexSize uint64) *transactionPositionsIndexer {
+	return &transactionPositionsIndexer{
+		store:     store,
+		indexSize: indexSize,
+	}
+}
+
+// Add implements Indexer, position being a TransactionPosition
+func (i *transactionPositionsIndexer) Add(keys []string, position uint64) {
+	blockNum := position >> transactionIndexBits
+
+	if i.current == nil {
+		if blockNum%i.indexSize != 0 && blockNum != bstream.GetProtocolFirstStreamableBlock {
+			zlog.Warn("couldn't determine transaction positions index boundary for block", zap.Uint64("blk_num", blockNum))
+			return
+		}
+		i.current = i.newBundle(blockNum)
+	}
+
+	if blockNum >= i.current.ExclusiveHighBlockNum() {
+		i.write()
+		i.current = i.newBundle(blockNum)
+	}
+
+	for _, key := range keys {
+		bitmap := i.current.Bitmaps[key]
+		if bitmap == nil {
+			bitmap = roaring64.NewBitmap()
+			i.current.Bitmaps[key] = bitmap
+		}
+		bitmap.Add(position)
+	}
+}
+
+func (i *transactionPositionsIndexer) newBundle(blockNum uint64) *CombinedIndexBundle {
+	return NewTransactionPositionsBundle(&CombinedIndexFile{LowBlockNum: blockNum - blockNum%i.indexSize, Size: i.indexSize})
+}
+
+func (i *transactionPositionsIndexer) write() {
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		err := i.current.Write(ctx, i.store)
+		cancel()
+
+		if err == nil {
+			zlog.Info("wrote transaction positions index file", zap.String("filename", i.current.Filename))
+			return
+		}
+
+		if attempt >= 3 {
+			zlog.Warn("couldn't write transaction positions index file", zap.String("filename", i.current.Filename), zap.Error(err))
+			return
+		}
+		zlog.Warn("cannot write transaction positions index file, retrying", zap.String("filename", i.current.Filename), zap.Int("attempt", attempt), zap.Error(err))
+		time.Sleep(time.Duration(attempt) * transactionPositionsWriteRetryDelay)
+	}
+}
+
+// transactionPositionsProvider returns the transactions of a block matched by the combined filter
+// according to the transaction positions index, loading one index file at a time
+type transactionPositionsProvider struct {
+	lock sync.Mutex
+
+	filter             *CombinedFilter
+	store              dstore.Store
+	possibleIndexSizes []uint64
+
+	loadedLowBlockNum           uint64
+	loadedExclusiveHighBlockNum uint64
+
+	// positions are the matching positions of the loaded range, nil when no index file covers it
+	positions *roaring64.Bitmap
+	// uncovered are the positions of the first transaction of the blocks not covered by the loaded
+	// index file
+	uncovered *roaring64.Bitmap
+}
+
+func newTransactionPositionsProvider(filter *CombinedFilter, store dstore.Store, possibleIndexSizes []uint64) *transactionPositionsProvider {
+	if len(possibleIndexSizes) == 0 {
+		possibleIndexSizes = defaultPossibleIndexSizes
+	}
+
+	return &transactionPositionsProvider{
+		filter:             filter,
+		store:              store,
+		possibleIndexSizes: possibleIndexSizes,
+	}
+}
+
+// transactionIndexes returns the indexes, in the block's transaction traces, of the transactions
+// matching the filter, found is false when no transaction positions index covers the block
+func (p *transactionPositionsProvider) transactionIndexes(blockNum uint64) (out []int, found bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if blockNum < p.loadedLowBlockNum || blockNum >= p.loadedExclusiveHighBlockNum {
+		p.load(blockNum)
+	}
+
+	if p.positions == nil || (p.uncovered != nil && p.uncovered.Contains(TransactionPosition(blockNum, 0))) {
+		return nil, false
+	}
+
+	it := p.positions.Iterator()
+	it.AdvanceIfNeeded(TransactionPosition(blockNum, 0))
+	for it.HasNext() {
+		position := it.Next()
+		if position>>transactionIndexBits != blockNum {
+			break
+		}
+		_, index := TransactionIndex(position)
+		out = append(out, index)
+	}
+	return out, true
+}
+
+func (p *transactionPositionsProvider) load(blockNum uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var largestSize uint64
+	for _, size := range p.possibleIndexSizes {
+		largestSize = max(largestSize, size)
+
+		lowBlockNum := blockNum - blockNum%size
+		bitmaps, err := readIndexBitmaps(ctx, p.store, transactionPositionsFilename(lowBlockNum, size))
+		if err != nil {
+			zlog.Debug("couldn't read transaction positions index file", zap.Uint64("low_block_num", lowBlockNum), zap.Uint64("index_size", size), zap.Error(err))
+			continue
+		}
+
+		p.positions = combinedFilterBitmap(p.filter, &CombinedIndexBundle{Bitmaps: bitmaps})
+		p.uncovered = bitmaps[transactionPositionsUncoveredKey]
+		p.loadedLowBlockNum = lowBlockNum
+		p.loadedExclusiveHighBlockNum = lowBlockNum + size
+		return
+	}
+
+	// Without index file, the transactions are all scanned until the next boundary of the largest
+	// index size, this avoids looking for index files on every block when they are not produced
+	p.positions = nil
+	p.uncovered = nil
+	p.loadedLowBlockNum = blockNum
+	p.loadedExclusiveHighBlockNum = blockNum - blockNum%largestSize + largestSize
+}
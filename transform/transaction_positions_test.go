@@ -0,0 +1,178 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// previousTransformInput is the input of a transform receiving the block output by a previous one
+type previousTransformInput struct {
+	blk *pbeth.Block
+}
+
+func (i previousTransformInput) Type() string       { return "sf.ethereum.type.v2.Block" }
+func (i previousTransformInput) Obj() proto.Message { return i.blk }
+
+func positionsTestBlock(num uint64, traces ...*pbeth.TransactionTrace) *pbeth.Block {
+	return &pbeth.Block{Number: num, Header: &pbeth.BlockHeader{Number: num}, TransactionTraces: traces}
+}
+
+func traceIndexes(blk *pbeth.Block) (out []uint32) {
+	for _, trace := range blk.TransactionTraces {
+		out = append(out, trace.Index)
+	}
+	return
+}
+
+func TestEthCombinedIndexer_TransactionPositions(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	indexer := &EthCombinedIndexer{
+		BlockIndexer:         testBitmaps{},
+		TransactionPositions: newTransactionPositionsIndexer(store, 10),
+	}
+
+	for num := uint64(0); num <= 10; num++ {
+		blk := &pbeth.Block{Number: num}
+		switch num {
+		case 3:
+			blk.TransactionTraces = []*pbeth.TransactionTrace{transferTrace(walletA, walletB), transferTrace(walletB, walletA)}
+		case 7:
+			blk.TransactionTraces = []*pbeth.TransactionTrace{transferTrace(walletB, walletB)}
+		}
+		require.NoError(t, indexer.ProcessBlock(blk))
+	}
+
+	// The range is written once a block of the next range is seen, like the block index
+	bundle, err := ReadCombinedIndexBundle(context.Background(), store, &CombinedIndexFile{Filename: "0000000000.10.combinedtrx.idx", LowBlockNum: 0, Size: 10})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint64{TransactionPosition(3, 0), TransactionPosition(3, 1), TransactionPosition(7, 0)}, bundle.Get(IdxPrefixLog+tokenAddr.String()).ToArray())
+	assert.Equal(t, []uint64{TransactionPosition(3, 1), TransactionPosition(7, 0)}, bundle.Get(IdxPrefixLogTopic1+walletB.String()).ToArray())
+}
+
+func TestNewTransactionPositionsBundle(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	indexer := &EthCombinedIndexer{
+		BlockIndexer:         testBitmaps{},
+		TransactionPositions: newTransactionPositionsIndexer(store, 10),
+	}
+
+	file := &CombinedIndexFile{Filename: "0000000000.10.combined.idx", LowBlockNum: 0, Size: 10}
+	rebuilt := NewTransactionPositionsBundle(file)
+	rebuilder := &EthCombinedIndexer{BlockIndexer: testBitmaps{}, TransactionPositions: rebuilt}
+
+	for num := uint64(0); num <= 10; num++ {
+		blk := &pbeth.Block{Number: num}
+		switch num {
+		case 3:
+			blk.TransactionTraces = []*pbeth.TransactionTrace{transferTrace(walletA, walletB), transferTrace(walletB, walletA)}
+		case 10:
+			// Outside of the rebuilt bundle's range
+			blk.TransactionTraces = []*pbeth.TransactionTrace{transferTrace(walletB, walletB)}
+		}
+		require.NoError(t, indexer.ProcessBlock(blk))
+		require.NoError(t, rebuilder.ProcessBlock(blk))
+	}
+
+	assert.Equal(t, "0000000000.10.combinedtrx.idx", rebuilt.Filename)
+
+	written, err := ReadCombinedIndexBundle(context.Background(), store, rebuilt.CombinedIndexFile)
+	require.NoError(t, err)
+	assert.Empty(t, rebuilt.Diff(written))
+
+	blockNum, index := TransactionIndex(TransactionPosition(3, 1))
+	assert.Equal(t, uint64(3), blockNum)
+	assert.Equal(t, 1, index)
+}
+
+func TestCombinedFilter_TransactionPositions(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	indexer := &EthCombinedIndexer{
+		BlockIndexer:         testBitmaps{},
+		TransactionPositions: newTransactionPositionsIndexer(store, 10),
+	}
+
+	traces := []*pbeth.TransactionTrace{transferTrace(walletA, walletB), transferTrace(walletB, walletA), transferTrace(walletB, walletB)}
+	for i, trace := range traces {
+		trace.Index = uint32(i)
+	}
+	for num := uint64(0); num <= 10; num++ {
+		blk := &pbeth.Block{Number: num}
+		if num == 5 {
+			blk.TransactionTraces = traces
+		}
+		require.NoError(t, indexer.ProcessBlock(blk))
+	}
+
+	filter, err := newCombinedFilter(&pbtransform.CombinedFilter{
+		LogFilters: []*pbtransform.LogFilter{{Topic1: [][]byte{walletB}}},
+	}, store, []uint64{10})
+	require.NoError(t, err)
+
+	indexes, found := filter.transactionPositions.transactionIndexes(5)
+	require.True(t, found)
+	assert.Equal(t, []int{1, 2}, indexes)
+
+	output, err := filter.Transform(testBlock(t, positionsTestBlock(5, traces...)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, traceIndexes(output.(*pbeth.Block)))
+
+	// A block coming from a previous transform may not have the indexed transactions at the same
+	// positions, all its transactions are scanned
+	output, err = filter.Transform(testBlock(t, positionsTestBlock(5)), previousTransformInput{positionsTestBlock(5, traces[1], traces[0], traces[2])})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, traceIndexes(output.(*pbeth.Block)))
+
+	// Not covered by a transaction positions index file, all transactions are scanned
+	_, found = filter.transactionPositions.transactionIndexes(15)
+	assert.False(t, found)
+
+	output, err = filter.Transform(testBlock(t, positionsTestBlock(15, traces...)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, traceIndexes(output.(*pbeth.Block)))
+}
+
+func TestEthCombinedIndexer_TransactionPositions_TooManyTransactions(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	indexer := &EthCombinedIndexer{
+		BlockIndexer:         testBitmaps{},
+		TransactionPositions: newTransactionPositionsIndexer(store, 10),
+	}
+
+	tooMany := make([]*pbeth.TransactionTrace, maxTransactionsPerBlock+1)
+	for i := range tooMany {
+		tooMany[i] = &pbeth.TransactionTrace{Receipt: &pbeth.TransactionReceipt{}}
+	}
+	tooMany[1] = transferTrace(walletB, walletA)
+
+	for num := uint64(0); num <= 10; num++ {
+		blk := &pbeth.Block{Number: num}
+		switch num {
+		case 3:
+			blk.TransactionTraces = []*pbeth.TransactionTrace{transferTrace(walletA, walletB), transferTrace(walletB, walletA)}
+		case 4:
+			blk.TransactionTraces = tooMany
+		}
+		require.NoError(t, indexer.ProcessBlock(blk))
+	}
+
+	filter, err := newCombinedFilter(&pbtransform.CombinedFilter{
+		LogFilters: []*pbtransform.LogFilter{{Topic1: [][]byte{walletB}}},
+	}, store, []uint64{10})
+	require.NoError(t, err)
+
+	indexes, found := filter.transactionPositions.transactionIndexes(3)
+	require.True(t, found)
+	assert.Equal(t, []int{1}, indexes)
+
+	// The transactions of the block are not in the index, they are all scanned
+	_, found = filter.transactionPositions.transactionIndexes(4)
+	assert.False(t, found)
+}
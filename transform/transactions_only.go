@@ -0,0 +1,64 @@
+package transform
+
+import (
+	"fmt"
+
+	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var TransactionsOnlyMessageName = proto.MessageName(&pbtransform.TransactionsOnly{})
+
+func NewTransactionsOnlyTransformFactory(_ dstore.Store, _ []uint64) (*transform.Factory, error) {
+	return TransactionsOnlyTransformFactory, nil
+}
+
+var TransactionsOnlyTransformFactory = &transform.Factory{
+	Obj: &pbtransform.TransactionsOnly{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != TransactionsOnlyMessageName {
+			return nil, fmt.Errorf("expected type url %q, received %q ", TransactionsOnlyMessageName, message.TypeUrl)
+		}
+
+		return &TransactionsOnly{}, nil
+	},
+}
+
+type TransactionsOnly struct{}
+
+func (p *TransactionsOnly) String() string {
+	return "Transactions only"
+}
+
+func (p *TransactionsOnly) Transform(readOnlyBlk *pbbstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := blockFromInput(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionTracesWithBlockRef(ethBlock), nil
+}
+
+func transactionTracesWithBlockRef(block *pbeth.Block) *pbtransform.TransactionTraces {
+	blockRef := &pbeth.BlockRef{
+		Hash:   block.Hash,
+		Number: block.Number,
+	}
+
+	out := &pbtransform.TransactionTraces{
+		TransactionTraces: make([]*pbeth.TransactionTraceWithBlockRef, len(block.TransactionTraces)),
+	}
+	for i, trace := range block.TransactionTraces {
+		out.TransactionTraces[i] = &pbeth.TransactionTraceWithBlockRef{
+			Trace:    trace,
+			BlockRef: blockRef,
+		}
+	}
+	return out
+}
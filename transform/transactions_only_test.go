@@ -0,0 +1,55 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestTransactionsOnly_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(TransactionsOnlyTransformFactory)
+
+	transactionsOnly, err := anypb.New(&pbtransform.TransactionsOnly{})
+	require.NoError(t, err)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{transactionsOnly})
+	require.NoError(t, err)
+
+	testBlock := testBlockFromFiles(t, "block.json")
+	block := &pbeth.Block{}
+	require.NoError(t, testBlock.Payload.UnmarshalTo(block))
+
+	output, err := preprocFunc(testBlock)
+	require.NoError(t, err)
+
+	traces := output.(*pbtransform.TransactionTraces)
+	require.Len(t, traces.TransactionTraces, len(block.TransactionTraces))
+	for i, trace := range traces.TransactionTraces {
+		assert.Equal(t, block.Hash, trace.BlockRef.Hash)
+		assert.Equal(t, block.Number, trace.BlockRef.Number)
+		assertProtoEqual(t, block.TransactionTraces[i], trace.Trace)
+	}
+}
+
+func TestTransactionsOnly_MustBeLast(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(TransactionsOnlyTransformFactory)
+	transformReg.Register(FieldProjectionTransformFactory)
+
+	transactionsOnly, err := anypb.New(&pbtransform.TransactionsOnly{})
+	require.NoError(t, err)
+	projection, err := anypb.New(&pbtransform.FieldProjection{DropGasChanges: true})
+	require.NoError(t, err)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{transactionsOnly, projection})
+	require.NoError(t, err)
+
+	_, err = preprocFunc(testBlockFromFiles(t, "block.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package transform
+
+import (
+	"math/big"
+
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+)
+
+type ValueTransferFilter struct {
+	addresses []eth.Address
+	from      []eth.Address
+	to        []eth.Address
+	minValue  *big.Int
+}
+
+func (f *ValueTransferFilter) Addresses() []eth.Address {
+	return f.addresses
+}
+
+func (f *ValueTransferFilter) From() []eth.Address {
+	return f.from
+}
+
+func (f *ValueTransferFilter) To() []eth.Address {
+	return f.to
+}
+
+// MinValue returns the minimum amount in wei of the transfers to match, nil if unset
+func (f *ValueTransferFilter) MinValue() *big.Int {
+	return f.minValue
+}
+
+func NewValueTransferFilter(in *pbtransform.ValueTransferFilter) (*ValueTransferFilter, error) {
+	f := &ValueTransferFilter{
+		addresses: make([]eth.Address, 0, len(in.Addresses)),
+		from:      make([]eth.Address, 0, len(in.From)),
+		to:        make([]eth.Address, 0, len(in.To)),
+	}
+	for _, addr := range in.Addresses {
+		f.addresses = append(f.addresses, addr)
+	}
+	for _, addr := range in.From {
+		f.from = append(f.from, addr)
+	}
+	for _, addr := range in.To {
+		f.to = append(f.to, addr)
+	}
+	if in.MinValue != nil && len(in.MinValue.Bytes) != 0 {
+		f.minValue = in.MinValue.Native()
+	}
+
+	return f, nil
+}
+
+func (p *ValueTransferFilter) matchesTransfer(from, to eth.Address, value *big.Int) bool {
+	if len(p.addresses) != 0 && !matchAnyAddress(p.addresses, from) && !matchAnyAddress(p.addresses, to) {
+		return false
+	}
+	if p.minValue != nil && value.Cmp(p.minValue) < 0 {
+		return false
+	}
+	return matchAnyAddress(p.from, from) && matchAnyAddress(p.to, to)
+}
+
+func (p *ValueTransferFilter) matches(trace *pbeth.TransactionTrace) bool {
+	if len(trace.Calls) == 0 {
+		from, to, value, ok := transactionValueTransfer(trace)
+		return ok && p.matchesTransfer(from, to, value)
+	}
+
+	for _, call := range trace.Calls {
+		if p.matchesCall(call) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ValueTransferFilter) matchesCall(call *pbeth.Call) bool {
+	if from, to, value, ok := callValueTransfer(call); ok && p.matchesTransfer(from, to, value) {
+		return true
+	}
+
+	for _, transfer := range balanceChangeTransfers(call) {
+		if p.matchesTransfer(transfer.from, transfer.to, transfer.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionValueTransfer returns the value moved by the transaction itself, used when the
+// calls of the transaction are not available (e.g. on BASE detail level blocks)
+func transactionValueTransfer(trace *pbeth.TransactionTrace) (from, to eth.Address, value *big.Int, ok bool) {
+	if trace.Status != pbeth.TransactionTraceStatus_SUCCEEDED || trace.Value == nil {
+		return nil, nil, nil, false
+	}
+
+	value = trace.Value.Native()
+	if value.Sign() == 0 {
+		return nil, nil, nil, false
+	}
+	return trace.From, trace.To, value, true
+}
+
+// callValueTransfer returns the value moved by the call, DELEGATE and CALLCODE calls are skipped
+// since their value is not moved to the call's address
+func callValueTransfer(call *pbeth.Call) (from, to eth.Address, value *big.Int, ok bool) {
+	if call.StateReverted || call.Value == nil || call.CallType == pbeth.CallType_DELEGATE || call.CallType == pbeth.CallType_CALLCODE {
+		return nil, nil, nil, false
+	}
+
+	value = call.Value.Native()
+	if value.Sign() == 0 {
+		return nil, nil, nil, false
+	}
+	return call.Caller, call.Address, value, true
+}
+
+// valueTransfer is an amount of wei moved from an address to another, an address being nil when
+// the balance changes it was found from do not tell it
+type valueTransfer struct {
+	from  eth.Address
+	to    eth.Address
+	value *big.Int
+}
+
+// isTransferBalanceChange is true for the balance changes moving value between addresses, a
+// CALL_BALANCE_OVERRIDE change overwrites a balance without moving value and is not one
+func isTransferBalanceChange(change *pbeth.BalanceChange) bool {
+	return change.Reason == pbeth.BalanceChange_REASON_TRANSFER
+}
+
+func balanceChangeDelta(change *pbeth.BalanceChange) *big.Int {
+	return new(big.Int).Sub(change.NewValue.Native(), change.OldValue.Native())
+}
+
+// balanceChangeTransfers returns the value moved by the TRANSFER balance changes of the call,
+// covering the transfers without a value carrying call. The node
+// debits the sender right before crediting the recipient, such a pair forms a single transfer,
+// any other change is a transfer whose other side is unknown.
+func balanceChangeTransfers(call *pbeth.Call) (out []*valueTransfer) {
+	if call.StateReverted {
+		return nil
+	}
+
+	for i := 0; i < len(call.BalanceChanges); i++ {
+		change := call.BalanceChanges[i]
+		if !isTransferBalanceChange(change) {
+			continue
+		}
+
+		delta := balanceChangeDelta(change)
+		switch delta.Sign() {
+		case 0:
+			continue
+		case 1:
+			out = append(out, &valueTransfer{to: change.Address, value: delta})
+			continue
+		}
+
+		transfer := &valueTransfer{from: change.Address, value: delta.Neg(delta)}
+		if i+1 < len(call.BalanceChanges) {
+			next := call.BalanceChanges[i+1]
+			if isTransferBalanceChange(next) && balanceChangeDelta(next).Cmp(transfer.value) == 0 {
+				transfer.to = next.Address
+				i++
+			}
+		}
+		out = append(out, transfer)
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package transform
+
+import (
+	"math/big"
+	"testing"
+
+	pbeth "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceChangeTransfers(t *testing.T) {
+	call := &pbeth.Call{BalanceChanges: []*pbeth.BalanceChange{
+		{Address: routerAddr, OldValue: pbeth.NewBigInt(30), NewValue: pbeth.NewBigInt(10), Reason: pbeth.BalanceChange_REASON_TRANSFER},
+		{Address: botAddr, OldValue: pbeth.NewBigInt(5), NewValue: pbeth.NewBigInt(25), Reason: pbeth.BalanceChange_REASON_TRANSFER},
+		{Address: userAddr, OldValue: pbeth.NewBigInt(50), NewValue: pbeth.NewBigInt(40), Reason: pbeth.BalanceChange_REASON_GAS_BUY},
+		{Address: userAddr, NewValue: pbeth.NewBigInt(3), Reason: pbeth.BalanceChange_REASON_CALL_BALANCE_OVERRIDE},
+	}}
+
+	transfers := balanceChangeTransfers(call)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, &valueTransfer{from: routerAddr, to: botAddr, value: big.NewInt(20)}, transfers[0])
+
+	call.StateReverted = true
+	assert.Empty(t, balanceChangeTransfers(call))
+}
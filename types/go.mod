@@ -5,6 +5,7 @@ go 1.22.0
 require (
 	github.com/mitchellh/go-testing-interface v1.14.1
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.23.0
 	google.golang.org/protobuf v1.33.0
 )
 
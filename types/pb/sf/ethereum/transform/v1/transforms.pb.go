@@ -7,6 +7,7 @@
 package pbtransform
 
 import (
+	v2 "github.com/streamingfast/firehose-ethereum/types/pb/sf/ethereum/type/v2"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -38,6 +39,12 @@ const (
 // The SKIPPING feature only applies to historical blocks, because
 // the "block index" is always produced after the merged-blocks files
 // are produced. Therefore, the "live" blocks are never filtered out.
+//
+// The "exclude_*" filters are applied after the inclusion ones: a transaction
+// matching any of the inclusion filters is still removed if it matches any of the
+// exclusion filters. When no inclusion filter is provided, all transactions are
+// considered included and only the exclusion filters apply, in which case the
+// SKIPPING feature is not available.
 type CombinedFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -47,7 +54,30 @@ type CombinedFilter struct {
 	CallFilters []*CallToFilter `protobuf:"bytes,2,rep,name=call_filters,json=callFilters,proto3" json:"call_filters,omitempty"`
 	// Always send all blocks. if they don't match any log_filters or call_filters,
 	// all the transactions will be filtered out, sending only the header.
-	SendAllBlockHeaders bool `protobuf:"varint,3,opt,name=send_all_block_headers,json=sendAllBlockHeaders,proto3" json:"send_all_block_headers,omitempty"`
+	SendAllBlockHeaders       bool                 `protobuf:"varint,3,opt,name=send_all_block_headers,json=sendAllBlockHeaders,proto3" json:"send_all_block_headers,omitempty"`
+	ExcludeLogFilters         []*LogFilter         `protobuf:"bytes,4,rep,name=exclude_log_filters,json=excludeLogFilters,proto3" json:"exclude_log_filters,omitempty"`
+	ExcludeCallFilters        []*CallToFilter      `protobuf:"bytes,5,rep,name=exclude_call_filters,json=excludeCallFilters,proto3" json:"exclude_call_filters,omitempty"`
+	TransactionFilters        []*TransactionFilter `protobuf:"bytes,6,rep,name=transaction_filters,json=transactionFilters,proto3" json:"transaction_filters,omitempty"`
+	ExcludeTransactionFilters []*TransactionFilter `protobuf:"bytes,7,rep,name=exclude_transaction_filters,json=excludeTransactionFilters,proto3" json:"exclude_transaction_filters,omitempty"`
+	// When enabled, the calls of each transaction kept are pruned down to the calls matched
+	// by one of the call_filters, log_filters (the calls having emitted a matching log),
+	// balance_change_filters, storage_change_filters, contract_creation_filters or
+	// value_transfer_filters, including the ones of filter_groups, along with all their
+	// ancestors up to the root call, which is always kept. Transaction filters do not match
+	// individual calls, a transaction kept only by them is pruned down to its root call. The
+	// remaining calls keep their original `index` and `parent_index`, so they can be correlated
+	// with unpruned data, and `index` is thus not sequential anymore. The transaction level
+	// fields and the receipt are kept untouched.
+	PruneCalls bool `protobuf:"varint,8,opt,name=prune_calls,json=pruneCalls,proto3" json:"prune_calls,omitempty"`
+	// When at least one balance change filter is provided, the block level `balance_changes`
+	// are also reduced to the ones matching one of them.
+	BalanceChangeFilters    []*BalanceChangeFilter    `protobuf:"bytes,9,rep,name=balance_change_filters,json=balanceChangeFilters,proto3" json:"balance_change_filters,omitempty"`
+	StorageChangeFilters    []*StorageChangeFilter    `protobuf:"bytes,10,rep,name=storage_change_filters,json=storageChangeFilters,proto3" json:"storage_change_filters,omitempty"`
+	ContractCreationFilters []*ContractCreationFilter `protobuf:"bytes,11,rep,name=contract_creation_filters,json=contractCreationFilters,proto3" json:"contract_creation_filters,omitempty"`
+	ValueTransferFilters    []*ValueTransferFilter    `protobuf:"bytes,12,rep,name=value_transfer_filters,json=valueTransferFilters,proto3" json:"value_transfer_filters,omitempty"`
+	// Each group matches the transactions matched by *ALL* of its filters, while
+	// groups are ORed together with all the filters above.
+	FilterGroups []*FilterGroup `protobuf:"bytes,13,rep,name=filter_groups,json=filterGroups,proto3" json:"filter_groups,omitempty"`
 }
 
 func (x *CombinedFilter) Reset() {
@@ -103,6 +133,143 @@ func (x *CombinedFilter) GetSendAllBlockHeaders() bool {
 	return false
 }
 
+func (x *CombinedFilter) GetExcludeLogFilters() []*LogFilter {
+	if x != nil {
+		return x.ExcludeLogFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetExcludeCallFilters() []*CallToFilter {
+	if x != nil {
+		return x.ExcludeCallFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetTransactionFilters() []*TransactionFilter {
+	if x != nil {
+		return x.TransactionFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetExcludeTransactionFilters() []*TransactionFilter {
+	if x != nil {
+		return x.ExcludeTransactionFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetPruneCalls() bool {
+	if x != nil {
+		return x.PruneCalls
+	}
+	return false
+}
+
+func (x *CombinedFilter) GetBalanceChangeFilters() []*BalanceChangeFilter {
+	if x != nil {
+		return x.BalanceChangeFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetStorageChangeFilters() []*StorageChangeFilter {
+	if x != nil {
+		return x.StorageChangeFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetContractCreationFilters() []*ContractCreationFilter {
+	if x != nil {
+		return x.ContractCreationFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetValueTransferFilters() []*ValueTransferFilter {
+	if x != nil {
+		return x.ValueTransferFilters
+	}
+	return nil
+}
+
+func (x *CombinedFilter) GetFilterGroups() []*FilterGroup {
+	if x != nil {
+		return x.FilterGroups
+	}
+	return nil
+}
+
+// FilterGroup will match transactions where *ALL* of its call_filters, log_filters and
+// transaction_filters match, each filter being allowed to match a different call or log of the
+// same transaction (e.g. a call to router X *AND* a Swap event emitted by pool Y). A group
+// requires at least one filter and never matches system calls.
+type FilterGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CallFilters        []*CallToFilter      `protobuf:"bytes,1,rep,name=call_filters,json=callFilters,proto3" json:"call_filters,omitempty"`
+	LogFilters         []*LogFilter         `protobuf:"bytes,2,rep,name=log_filters,json=logFilters,proto3" json:"log_filters,omitempty"`
+	TransactionFilters []*TransactionFilter `protobuf:"bytes,3,rep,name=transaction_filters,json=transactionFilters,proto3" json:"transaction_filters,omitempty"`
+}
+
+func (x *FilterGroup) Reset() {
+	*x = FilterGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterGroup) ProtoMessage() {}
+
+func (x *FilterGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterGroup.ProtoReflect.Descriptor instead.
+func (*FilterGroup) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterGroup) GetCallFilters() []*CallToFilter {
+	if x != nil {
+		return x.CallFilters
+	}
+	return nil
+}
+
+func (x *FilterGroup) GetLogFilters() []*LogFilter {
+	if x != nil {
+		return x.LogFilters
+	}
+	return nil
+}
+
+func (x *FilterGroup) GetTransactionFilters() []*TransactionFilter {
+	if x != nil {
+		return x.TransactionFilters
+	}
+	return nil
+}
+
 // MultiLogFilter concatenates the results of each LogFilter (inclusive OR)
 type MultiLogFilter struct {
 	state         protoimpl.MessageState
@@ -115,7 +282,7 @@ type MultiLogFilter struct {
 func (x *MultiLogFilter) Reset() {
 	*x = MultiLogFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -128,7 +295,7 @@ func (x *MultiLogFilter) String() string {
 func (*MultiLogFilter) ProtoMessage() {}
 
 func (x *MultiLogFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,7 +308,7 @@ func (x *MultiLogFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiLogFilter.ProtoReflect.Descriptor instead.
 func (*MultiLogFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{1}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *MultiLogFilter) GetLogFilters() []*LogFilter {
@@ -151,11 +318,20 @@ func (x *MultiLogFilter) GetLogFilters() []*LogFilter {
 	return nil
 }
 
-// LogFilter will match calls where *BOTH*
+// LogFilter will match calls where *ALL* of
 // * the contract address that emits the log is one in the provided addresses -- OR addresses list is empty --
 // * the event signature (topic.0) is one of the provided event_signatures -- OR event_signatures is empty --
+// * the first indexed argument (topic.1) is one of the provided topic1 -- OR topic1 is empty --
+// * the second indexed argument (topic.2) is one of the provided topic2 -- OR topic2 is empty --
+// * the third indexed argument (topic.3) is one of the provided topic3 -- OR topic3 is empty --
+//
+// Indexed arguments are always 32 bytes long in the log's topics, so an address must be left-padded
+// with zeroes to 32 bytes to be matched.
 //
-// a LogFilter with both empty addresses and event_signatures lists is invalid and will fail.
+// Large lists of addresses can be referenced through addresses_url instead of being sent inline,
+// see the field for details.
+//
+// a LogFilter with all of addresses, addresses_url, event_signatures, topic1, topic2 and topic3 empty is invalid and will fail.
 type LogFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -163,12 +339,20 @@ type LogFilter struct {
 
 	Addresses       [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	EventSignatures [][]byte `protobuf:"bytes,2,rep,name=event_signatures,json=eventSignatures,proto3" json:"event_signatures,omitempty"` // corresponds to the keccak of the event signature which is stores in topic.0
+	Topic1          [][]byte `protobuf:"bytes,3,rep,name=topic1,proto3" json:"topic1,omitempty"`
+	Topic2          [][]byte `protobuf:"bytes,4,rep,name=topic2,proto3" json:"topic2,omitempty"`
+	Topic3          [][]byte `protobuf:"bytes,5,rep,name=topic3,proto3" json:"topic3,omitempty"`
+	// Name or URL of a file, under the address lists store configured by the server operator (e.g.
+	// 'whales.txt' or 'gs://bucket/lists/whales.txt' for a store at 'gs://bucket/lists'), listing
+	// addresses in hex separated by new lines, spaces or commas, lines starting with '#' being ignored.
+	// Its addresses are added to the ones of addresses. The file is loaded and cached server-side.
+	AddressesUrl string `protobuf:"bytes,6,opt,name=addresses_url,json=addressesUrl,proto3" json:"addresses_url,omitempty"`
 }
 
 func (x *LogFilter) Reset() {
 	*x = LogFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -181,7 +365,7 @@ func (x *LogFilter) String() string {
 func (*LogFilter) ProtoMessage() {}
 
 func (x *LogFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +378,7 @@ func (x *LogFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogFilter.ProtoReflect.Descriptor instead.
 func (*LogFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{2}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LogFilter) GetAddresses() [][]byte {
@@ -211,6 +395,34 @@ func (x *LogFilter) GetEventSignatures() [][]byte {
 	return nil
 }
 
+func (x *LogFilter) GetTopic1() [][]byte {
+	if x != nil {
+		return x.Topic1
+	}
+	return nil
+}
+
+func (x *LogFilter) GetTopic2() [][]byte {
+	if x != nil {
+		return x.Topic2
+	}
+	return nil
+}
+
+func (x *LogFilter) GetTopic3() [][]byte {
+	if x != nil {
+		return x.Topic3
+	}
+	return nil
+}
+
+func (x *LogFilter) GetAddressesUrl() string {
+	if x != nil {
+		return x.AddressesUrl
+	}
+	return ""
+}
+
 // MultiCallToFilter concatenates the results of each CallToFilter (inclusive OR)
 type MultiCallToFilter struct {
 	state         protoimpl.MessageState
@@ -223,7 +435,7 @@ type MultiCallToFilter struct {
 func (x *MultiCallToFilter) Reset() {
 	*x = MultiCallToFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +448,7 @@ func (x *MultiCallToFilter) String() string {
 func (*MultiCallToFilter) ProtoMessage() {}
 
 func (x *MultiCallToFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +461,7 @@ func (x *MultiCallToFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiCallToFilter.ProtoReflect.Descriptor instead.
 func (*MultiCallToFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{3}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MultiCallToFilter) GetCallFilters() []*CallToFilter {
@@ -259,24 +471,33 @@ func (x *MultiCallToFilter) GetCallFilters() []*CallToFilter {
 	return nil
 }
 
-// CallToFilter will match calls where *BOTH*
+// CallToFilter will match calls where *ALL* of
 // * the contract address (TO) is one in the provided addresses -- OR addresses list is empty --
 // * the method signature (in 4-bytes format) is one of the provided signatures -- OR signatures is empty --
+// * the call type is one of the provided call_types -- OR call_types is empty --
+// * the call depth is lower or equal to max_depth, the root call being at depth 0 -- OR max_depth is unset --
+// * the call did not fail (its status_failed is false) -- OR succeeded_only is false --
 //
-// a CallToFilter with both empty addresses and signatures lists is invalid and will fail.
+// Large lists of addresses can be referenced through addresses_url, see LogFilter.addresses_url.
+//
+// a CallToFilter with all of addresses, addresses_url and signatures empty is invalid and will fail.
 type CallToFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Addresses  [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	Signatures [][]byte `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Addresses     [][]byte      `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Signatures    [][]byte      `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	CallTypes     []v2.CallType `protobuf:"varint,3,rep,packed,name=call_types,json=callTypes,proto3,enum=sf.ethereum.type.v2.CallType" json:"call_types,omitempty"`
+	MaxDepth      *uint32       `protobuf:"varint,4,opt,name=max_depth,json=maxDepth,proto3,oneof" json:"max_depth,omitempty"`
+	SucceededOnly bool          `protobuf:"varint,5,opt,name=succeeded_only,json=succeededOnly,proto3" json:"succeeded_only,omitempty"`
+	AddressesUrl  string        `protobuf:"bytes,6,opt,name=addresses_url,json=addressesUrl,proto3" json:"addresses_url,omitempty"`
 }
 
 func (x *CallToFilter) Reset() {
 	*x = CallToFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -289,7 +510,7 @@ func (x *CallToFilter) String() string {
 func (*CallToFilter) ProtoMessage() {}
 
 func (x *CallToFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,7 +523,7 @@ func (x *CallToFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallToFilter.ProtoReflect.Descriptor instead.
 func (*CallToFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{4}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CallToFilter) GetAddresses() [][]byte {
@@ -319,47 +540,70 @@ func (x *CallToFilter) GetSignatures() [][]byte {
 	return nil
 }
 
-// HeaderOnly returns only the block's header and few top-level core information for the block. Useful
-// for cases where no transactions information is required at all.
-//
-// The structure that would will have access to after:
-//
-// ```
-//
-//	Block {
-//	 int32 ver = 1;
-//	 bytes hash = 2;
-//	 uint64 number = 3;
-//	 uint64 size = 4;
-//	 BlockHeader header = 5;
-//	}
+func (x *CallToFilter) GetCallTypes() []v2.CallType {
+	if x != nil {
+		return x.CallTypes
+	}
+	return nil
+}
+
+func (x *CallToFilter) GetMaxDepth() uint32 {
+	if x != nil && x.MaxDepth != nil {
+		return *x.MaxDepth
+	}
+	return 0
+}
+
+func (x *CallToFilter) GetSucceededOnly() bool {
+	if x != nil {
+		return x.SucceededOnly
+	}
+	return false
+}
+
+func (x *CallToFilter) GetAddressesUrl() string {
+	if x != nil {
+		return x.AddressesUrl
+	}
+	return ""
+}
+
+// TransactionFilter will match transactions where *ALL* of
+// * the sender (FROM) is one in the provided from addresses -- OR from list is empty --
+// * the recipient (TO) is one in the provided to addresses -- OR to list is empty --
+// * the method signature (in 4-bytes format) of the transaction's input is one of the provided signatures -- OR signatures is empty --
 //
-// ```
+// Unlike CallToFilter, only the top-level transaction is considered and never its calls, which
+// means it also works on blocks with a detail level of BASE.
 //
-// Everything else will be empty.
-type HeaderOnly struct {
+// a TransactionFilter with all of from, to and signatures lists empty is invalid and will fail.
+type TransactionFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	From       [][]byte `protobuf:"bytes,1,rep,name=from,proto3" json:"from,omitempty"`
+	To         [][]byte `protobuf:"bytes,2,rep,name=to,proto3" json:"to,omitempty"`
+	Signatures [][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
 }
 
-func (x *HeaderOnly) Reset() {
-	*x = HeaderOnly{}
+func (x *TransactionFilter) Reset() {
+	*x = TransactionFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HeaderOnly) String() string {
+func (x *TransactionFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeaderOnly) ProtoMessage() {}
+func (*TransactionFilter) ProtoMessage() {}
 
-func (x *HeaderOnly) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
+func (x *TransactionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,103 +614,1041 @@ func (x *HeaderOnly) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeaderOnly.ProtoReflect.Descriptor instead.
-func (*HeaderOnly) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use TransactionFilter.ProtoReflect.Descriptor instead.
+func (*TransactionFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{6}
 }
 
-var File_sf_ethereum_transform_v1_transforms_proto protoreflect.FileDescriptor
+func (x *TransactionFilter) GetFrom() [][]byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
 
-var file_sf_ethereum_transform_v1_transforms_proto_rawDesc = []byte{
-	0x0a, 0x29, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x73, 0x66, 0x2e,
-	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
-	0x72, 0x6d, 0x2e, 0x76, 0x31, 0x22, 0xd6, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e,
-	0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
-	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x49,
-	0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x63, 0x61,
-	0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x65, 0x6e,
-	0x64, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x65, 0x6e, 0x64, 0x41,
-	0x6c, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x22, 0x56,
-	0x0a, 0x0e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
-	0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31,
-	0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x54, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x11,
-	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x12, 0x49, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68,
-	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x0c,
-	0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67,
-	0x66, 0x61, 0x73, 0x74, 0x2f, 0x66, 0x69, 0x72, 0x65, 0x68, 0x6f, 0x73, 0x65, 0x2d, 0x65, 0x74,
-	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x2f,
-	0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TransactionFilter) GetTo() [][]byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
 }
 
-var (
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce sync.Once
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescData = file_sf_ethereum_transform_v1_transforms_proto_rawDesc
-)
+func (x *TransactionFilter) GetSignatures() [][]byte {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
 
-func file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP() []byte {
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce.Do(func() {
-		file_sf_ethereum_transform_v1_transforms_proto_rawDescData = protoimpl.X.CompressGZIP(file_sf_ethereum_transform_v1_transforms_proto_rawDescData)
-	})
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescData
+// BalanceChangeFilter will match transactions having a call with a balance change where *BOTH*
+// * the address whose balance changed is one in the provided addresses -- OR addresses list is empty --
+// * the reason of the balance change is one of the provided reasons -- OR reasons list is empty --
+//
+// It also matches the block level balance changes (e.g. block rewards and withdrawals) with the same rules.
+//
+// a BalanceChangeFilter with both empty addresses and reasons lists is invalid and will fail.
+type BalanceChangeFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses [][]byte                  `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Reasons   []v2.BalanceChange_Reason `protobuf:"varint,2,rep,packed,name=reasons,proto3,enum=sf.ethereum.type.v2.BalanceChange_Reason" json:"reasons,omitempty"`
 }
 
-var file_sf_ethereum_transform_v1_transforms_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_sf_ethereum_transform_v1_transforms_proto_goTypes = []interface{}{
-	(*CombinedFilter)(nil),    // 0: sf.ethereum.transform.v1.CombinedFilter
-	(*MultiLogFilter)(nil),    // 1: sf.ethereum.transform.v1.MultiLogFilter
-	(*LogFilter)(nil),         // 2: sf.ethereum.transform.v1.LogFilter
-	(*MultiCallToFilter)(nil), // 3: sf.ethereum.transform.v1.MultiCallToFilter
-	(*CallToFilter)(nil),      // 4: sf.ethereum.transform.v1.CallToFilter
-	(*HeaderOnly)(nil),        // 5: sf.ethereum.transform.v1.HeaderOnly
+func (x *BalanceChangeFilter) Reset() {
+	*x = BalanceChangeFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_sf_ethereum_transform_v1_transforms_proto_depIdxs = []int32{
-	2, // 0: sf.ethereum.transform.v1.CombinedFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
-	4, // 1: sf.ethereum.transform.v1.CombinedFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
-	2, // 2: sf.ethereum.transform.v1.MultiLogFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
-	4, // 3: sf.ethereum.transform.v1.MultiCallToFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *BalanceChangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_sf_ethereum_transform_v1_transforms_proto_init() }
-func file_sf_ethereum_transform_v1_transforms_proto_init() {
-	if File_sf_ethereum_transform_v1_transforms_proto != nil {
-		return
+func (*BalanceChangeFilter) ProtoMessage() {}
+
+func (x *BalanceChangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceChangeFilter.ProtoReflect.Descriptor instead.
+func (*BalanceChangeFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BalanceChangeFilter) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *BalanceChangeFilter) GetReasons() []v2.BalanceChange_Reason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+// StorageChangeFilter will match transactions having a call that wrote the storage where *BOTH*
+// * the contract address whose storage changed is one in the provided addresses
+// * the storage slot key (32 bytes) is one of the provided keys -- OR keys list is empty --
+//
+// Storage changes of calls whose state was reverted are ignored since they never made it
+// to the chain's state.
+//
+// a StorageChangeFilter with an empty addresses list is invalid and will fail.
+type StorageChangeFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Keys      [][]byte `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *StorageChangeFilter) Reset() {
+	*x = StorageChangeFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageChangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageChangeFilter) ProtoMessage() {}
+
+func (x *StorageChangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageChangeFilter.ProtoReflect.Descriptor instead.
+func (*StorageChangeFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageChangeFilter) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *StorageChangeFilter) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// ContractCreationFilter will match transactions having deployed a contract (a call of type CREATE,
+// which also covers CREATE2, whose state was not reverted) where *BOTH*
+// * the deployer, the caller of the CREATE call, is one in the provided deployers -- OR deployers list is empty --
+// * the deployed code hash starts with one of the provided code_hash_prefixes -- OR code_hash_prefixes is empty --
+//
+// Unlike other filters, a ContractCreationFilter with both empty lists is valid and matches all deployments.
+type ContractCreationFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deployers        [][]byte `protobuf:"bytes,1,rep,name=deployers,proto3" json:"deployers,omitempty"`
+	CodeHashPrefixes [][]byte `protobuf:"bytes,2,rep,name=code_hash_prefixes,json=codeHashPrefixes,proto3" json:"code_hash_prefixes,omitempty"`
+}
+
+func (x *ContractCreationFilter) Reset() {
+	*x = ContractCreationFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContractCreationFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractCreationFilter) ProtoMessage() {}
+
+func (x *ContractCreationFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractCreationFilter.ProtoReflect.Descriptor instead.
+func (*ContractCreationFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ContractCreationFilter) GetDeployers() [][]byte {
+	if x != nil {
+		return x.Deployers
+	}
+	return nil
+}
+
+func (x *ContractCreationFilter) GetCodeHashPrefixes() [][]byte {
+	if x != nil {
+		return x.CodeHashPrefixes
+	}
+	return nil
+}
+
+// ValueTransferFilter will match transactions having moved native value (Ether), either through
+// the transaction itself, through an internal call or through the `TRANSFER` balance changes
+// of a call, whose state was not reverted, where *ALL*
+// * the sender or the recipient of the transfer is one in the provided addresses -- OR addresses list is empty --
+// * the sender of the transfer is one in the provided from -- OR from list is empty --
+// * the recipient of the transfer is one in the provided to -- OR to list is empty --
+// * the transferred amount, in wei, is greater or equal to min_value -- OR min_value is unset --
+//
+// Delegate calls and `CALL_BALANCE_OVERRIDE` balance changes are not considered as they do not
+// move value. A ValueTransferFilter with all fields empty is valid and matches all value transfers.
+type ValueTransferFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses [][]byte   `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	From      [][]byte   `protobuf:"bytes,2,rep,name=from,proto3" json:"from,omitempty"`
+	To        [][]byte   `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+	MinValue  *v2.BigInt `protobuf:"bytes,4,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+}
+
+func (x *ValueTransferFilter) Reset() {
+	*x = ValueTransferFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValueTransferFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValueTransferFilter) ProtoMessage() {}
+
+func (x *ValueTransferFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValueTransferFilter.ProtoReflect.Descriptor instead.
+func (*ValueTransferFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValueTransferFilter) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ValueTransferFilter) GetFrom() [][]byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ValueTransferFilter) GetTo() [][]byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ValueTransferFilter) GetMinValue() *v2.BigInt {
+	if x != nil {
+		return x.MinValue
+	}
+	return nil
+}
+
+// TransactionsOnly turns the block into a flat TransactionTraces message holding each of its
+// transaction traces along with a reference to the block, sparing transaction oriented consumers
+// from unpacking the block. It is usually chained after a CombinedFilter so that only the
+// matched transactions are sent and, since its output is not a block anymore, it must be the
+// last transform of the chain.
+type TransactionsOnly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TransactionsOnly) Reset() {
+	*x = TransactionsOnly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionsOnly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionsOnly) ProtoMessage() {}
+
+func (x *TransactionsOnly) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionsOnly.ProtoReflect.Descriptor instead.
+func (*TransactionsOnly) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{11}
+}
+
+// TransactionTraces is the output of the TransactionsOnly transform, it is sent for each block
+// even when it contains no transaction so that the stream's cursor keeps progressing.
+type TransactionTraces struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionTraces []*v2.TransactionTraceWithBlockRef `protobuf:"bytes,1,rep,name=transaction_traces,json=transactionTraces,proto3" json:"transaction_traces,omitempty"`
+}
+
+func (x *TransactionTraces) Reset() {
+	*x = TransactionTraces{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionTraces) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionTraces) ProtoMessage() {}
+
+func (x *TransactionTraces) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionTraces.ProtoReflect.Descriptor instead.
+func (*TransactionTraces) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TransactionTraces) GetTransactionTraces() []*v2.TransactionTraceWithBlockRef {
+	if x != nil {
+		return x.TransactionTraces
+	}
+	return nil
+}
+
+// LogsOnly turns the block into a flat Logs message holding its logs, ordered by ordinal, each with
+// a reference to its transaction and block. When log_filters are provided, only the logs matching
+// one of them are kept, which is usually combined with the same log_filters in a CombinedFilter
+// chained before it so that blocks without matches are skipped using the index. Like
+// TransactionsOnly, its output is not a block anymore so it must be the last transform of the chain.
+type LogsOnly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogFilters []*LogFilter `protobuf:"bytes,1,rep,name=log_filters,json=logFilters,proto3" json:"log_filters,omitempty"`
+	// include_reverted_logs also keeps the logs emitted by calls whose state was reverted,
+	// those are excluded by default as they never made it to the chain
+	IncludeRevertedLogs bool `protobuf:"varint,2,opt,name=include_reverted_logs,json=includeRevertedLogs,proto3" json:"include_reverted_logs,omitempty"`
+}
+
+func (x *LogsOnly) Reset() {
+	*x = LogsOnly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogsOnly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogsOnly) ProtoMessage() {}
+
+func (x *LogsOnly) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogsOnly.ProtoReflect.Descriptor instead.
+func (*LogsOnly) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LogsOnly) GetLogFilters() []*LogFilter {
+	if x != nil {
+		return x.LogFilters
+	}
+	return nil
+}
+
+func (x *LogsOnly) GetIncludeRevertedLogs() bool {
+	if x != nil {
+		return x.IncludeRevertedLogs
+	}
+	return false
+}
+
+// Logs is the output of the LogsOnly transform, it is sent for each block even when it
+// contains no log so that the stream's cursor keeps progressing.
+type Logs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logs []*LogWithRefs `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (x *Logs) Reset() {
+	*x = Logs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Logs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logs) ProtoMessage() {}
+
+func (x *Logs) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logs.ProtoReflect.Descriptor instead.
+func (*Logs) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Logs) GetLogs() []*LogWithRefs {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type LogWithRefs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Log *v2.Log `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	// reverted is true when the log was emitted by a call whose state was reverted, only
+	// possible when include_reverted_logs is set
+	Reverted         bool         `protobuf:"varint,2,opt,name=reverted,proto3" json:"reverted,omitempty"`
+	TransactionHash  []byte       `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex uint32       `protobuf:"varint,4,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	BlockRef         *v2.BlockRef `protobuf:"bytes,5,opt,name=block_ref,json=blockRef,proto3" json:"block_ref,omitempty"`
+}
+
+func (x *LogWithRefs) Reset() {
+	*x = LogWithRefs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogWithRefs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogWithRefs) ProtoMessage() {}
+
+func (x *LogWithRefs) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogWithRefs.ProtoReflect.Descriptor instead.
+func (*LogWithRefs) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LogWithRefs) GetLog() *v2.Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *LogWithRefs) GetReverted() bool {
+	if x != nil {
+		return x.Reverted
+	}
+	return false
+}
+
+func (x *LogWithRefs) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *LogWithRefs) GetTransactionIndex() uint32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *LogWithRefs) GetBlockRef() *v2.BlockRef {
+	if x != nil {
+		return x.BlockRef
+	}
+	return nil
+}
+
+// HeaderOnly returns only the block's header and few top-level core information for the block. Useful
+// for cases where no transactions information is required at all.
+//
+// The structure that would will have access to after:
+//
+// ```
+//
+//	Block {
+//	 int32 ver = 1;
+//	 bytes hash = 2;
+//	 uint64 number = 3;
+//	 uint64 size = 4;
+//	 BlockHeader header = 5;
+//	}
+//
+// ```
+//
+// Everything else will be empty.
+type HeaderOnly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HeaderOnly) Reset() {
+	*x = HeaderOnly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeaderOnly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderOnly) ProtoMessage() {}
+
+func (x *HeaderOnly) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderOnly.ProtoReflect.Descriptor instead.
+func (*HeaderOnly) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{16}
+}
+
+// FieldProjection removes fields from the block before it is sent, reducing the bandwidth
+// required for consumers not reading them. It can be used alone or after a CombinedFilter.
+//
+// The fields to remove can be specified with the predefined `drop_*` toggles and/or with
+// `drop_paths`, both being cumulative. HeaderOnly is a special case of FieldProjection
+// dropping everything except the block's header and few top-level core fields.
+type FieldProjection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Paths, relative to `sf.ethereum.type.v2.Block`, of the fields to remove. A path is made of
+	// the proto field names separated by dots, repeated fields being traversed transparently so
+	// that the path applies to each of their elements, for example `transaction_traces.calls.gas_changes`
+	// or `transaction_traces.receipt.logs_bloom`. Map fields can only appear as the last element
+	// of a path.
+	DropPaths []string `protobuf:"bytes,1,rep,name=drop_paths,json=dropPaths,proto3" json:"drop_paths,omitempty"`
+	// Removes `keccak_preimages` of every call, including system calls.
+	DropKeccakPreimages bool `protobuf:"varint,2,opt,name=drop_keccak_preimages,json=dropKeccakPreimages,proto3" json:"drop_keccak_preimages,omitempty"`
+	// Removes `gas_changes` of every call, including system calls.
+	DropGasChanges bool `protobuf:"varint,3,opt,name=drop_gas_changes,json=dropGasChanges,proto3" json:"drop_gas_changes,omitempty"`
+	// Removes `storage_changes` of every call, including system calls.
+	DropStorageChanges bool `protobuf:"varint,4,opt,name=drop_storage_changes,json=dropStorageChanges,proto3" json:"drop_storage_changes,omitempty"`
+	// Removes `input` of every call that is not a root call (`depth > 0`), including system calls.
+	DropDeepCallInputs bool `protobuf:"varint,5,opt,name=drop_deep_call_inputs,json=dropDeepCallInputs,proto3" json:"drop_deep_call_inputs,omitempty"`
+}
+
+func (x *FieldProjection) Reset() {
+	*x = FieldProjection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldProjection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldProjection) ProtoMessage() {}
+
+func (x *FieldProjection) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldProjection.ProtoReflect.Descriptor instead.
+func (*FieldProjection) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FieldProjection) GetDropPaths() []string {
+	if x != nil {
+		return x.DropPaths
+	}
+	return nil
+}
+
+func (x *FieldProjection) GetDropKeccakPreimages() bool {
+	if x != nil {
+		return x.DropKeccakPreimages
+	}
+	return false
+}
+
+func (x *FieldProjection) GetDropGasChanges() bool {
+	if x != nil {
+		return x.DropGasChanges
+	}
+	return false
+}
+
+func (x *FieldProjection) GetDropStorageChanges() bool {
+	if x != nil {
+		return x.DropStorageChanges
+	}
+	return false
+}
+
+func (x *FieldProjection) GetDropDeepCallInputs() bool {
+	if x != nil {
+		return x.DropDeepCallInputs
+	}
+	return false
+}
+
+var File_sf_ethereum_transform_v1_transforms_proto protoreflect.FileDescriptor
+
+var file_sf_ethereum_transform_v1_transforms_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x73, 0x66, 0x2e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x08, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x49,
+	0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x63, 0x61,
+	0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x65, 0x6e,
+	0x64, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x65, 0x6e, 0x64, 0x41,
+	0x6c, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x53,
+	0x0a, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x66,
+	0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x58, 0x0a, 0x14, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63,
+	0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c,
+	0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x5c, 0x0a,
+	0x13, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x73, 0x66, 0x2e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x6b, 0x0a, 0x1b, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x19, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x75, 0x6e,
+	0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70,
+	0x72, 0x75, 0x6e, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x63, 0x0a, 0x16, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x66, 0x2e, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x14, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x63,
+	0x0a, 0x16, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x14, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x6c, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65,
+	0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x63, 0x0a, 0x16, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x14, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x4a, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x49, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x44, 0x0a,
+	0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
+	0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x5c, 0x0a, 0x13, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x12, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x22, 0x56, 0x0a, 0x0e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c,
+	0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0xc1, 0x01, 0x0a, 0x09, 0x4c, 0x6f,
+	0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x31, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x31, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x32, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x32,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x33, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x33, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x55, 0x72, 0x6c, 0x22, 0x5e, 0x0a,
+	0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x49, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x86, 0x02,
+	0x0a, 0x0c, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0a,
+	0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x09, 0x63, 0x61, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
+	0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0e,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x4f,
+	0x6e, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x55, 0x72, 0x6c, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0x57, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x74, 0x6f, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22,
+	0x78, 0x0a, 0x13, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x52, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x22, 0x47, 0x0a, 0x13, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x22, 0x64, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x09, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f,
+	0x64, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x6f, 0x64, 0x65, 0x48, 0x61, 0x73, 0x68,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x13, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x38, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x69, 0x67, 0x49,
+	0x6e, 0x74, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x12, 0x0a, 0x10,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4f, 0x6e, 0x6c, 0x79,
+	0x22, 0x75, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x73, 0x12, 0x60, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x31, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x57, 0x69, 0x74, 0x68, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x66, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x73,
+	0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x66, 0x2e, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a,
+	0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x6c,
+	0x6f, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x73, 0x22, 0x41,
+	0x0a, 0x04, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x39, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x67, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x66, 0x73, 0x52, 0x04, 0x6c, 0x6f, 0x67,
+	0x73, 0x22, 0xe9, 0x01, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x66,
+	0x73, 0x12, 0x2a, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x3a, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x66, 0x52, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x22, 0x0c, 0x0a,
+	0x0a, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xf3, 0x01, 0x0a, 0x0f,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x72, 0x6f, 0x70, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x32,
+	0x0a, 0x15, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x6b, 0x65, 0x63, 0x63, 0x61, 0x6b, 0x5f, 0x70, 0x72,
+	0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x64,
+	0x72, 0x6f, 0x70, 0x4b, 0x65, 0x63, 0x63, 0x61, 0x6b, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x67, 0x61, 0x73, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x72,
+	0x6f, 0x70, 0x47, 0x61, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14,
+	0x64, 0x72, 0x6f, 0x70, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x64, 0x72, 0x6f, 0x70,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x31,
+	0x0a, 0x15, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x64, 0x65, 0x65, 0x70, 0x5f, 0x63, 0x61, 0x6c, 0x6c,
+	0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x64,
+	0x72, 0x6f, 0x70, 0x44, 0x65, 0x65, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x66, 0x69,
+	0x72, 0x65, 0x68, 0x6f, 0x73, 0x65, 0x2d, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65,
+	0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76,
+	0x31, 0x3b, 0x70, 0x62, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce sync.Once
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescData = file_sf_ethereum_transform_v1_transforms_proto_rawDesc
+)
+
+func file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP() []byte {
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce.Do(func() {
+		file_sf_ethereum_transform_v1_transforms_proto_rawDescData = protoimpl.X.CompressGZIP(file_sf_ethereum_transform_v1_transforms_proto_rawDescData)
+	})
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescData
+}
+
+var file_sf_ethereum_transform_v1_transforms_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_sf_ethereum_transform_v1_transforms_proto_goTypes = []interface{}{
+	(*CombinedFilter)(nil),                  // 0: sf.ethereum.transform.v1.CombinedFilter
+	(*FilterGroup)(nil),                     // 1: sf.ethereum.transform.v1.FilterGroup
+	(*MultiLogFilter)(nil),                  // 2: sf.ethereum.transform.v1.MultiLogFilter
+	(*LogFilter)(nil),                       // 3: sf.ethereum.transform.v1.LogFilter
+	(*MultiCallToFilter)(nil),               // 4: sf.ethereum.transform.v1.MultiCallToFilter
+	(*CallToFilter)(nil),                    // 5: sf.ethereum.transform.v1.CallToFilter
+	(*TransactionFilter)(nil),               // 6: sf.ethereum.transform.v1.TransactionFilter
+	(*BalanceChangeFilter)(nil),             // 7: sf.ethereum.transform.v1.BalanceChangeFilter
+	(*StorageChangeFilter)(nil),             // 8: sf.ethereum.transform.v1.StorageChangeFilter
+	(*ContractCreationFilter)(nil),          // 9: sf.ethereum.transform.v1.ContractCreationFilter
+	(*ValueTransferFilter)(nil),             // 10: sf.ethereum.transform.v1.ValueTransferFilter
+	(*TransactionsOnly)(nil),                // 11: sf.ethereum.transform.v1.TransactionsOnly
+	(*TransactionTraces)(nil),               // 12: sf.ethereum.transform.v1.TransactionTraces
+	(*LogsOnly)(nil),                        // 13: sf.ethereum.transform.v1.LogsOnly
+	(*Logs)(nil),                            // 14: sf.ethereum.transform.v1.Logs
+	(*LogWithRefs)(nil),                     // 15: sf.ethereum.transform.v1.LogWithRefs
+	(*HeaderOnly)(nil),                      // 16: sf.ethereum.transform.v1.HeaderOnly
+	(*FieldProjection)(nil),                 // 17: sf.ethereum.transform.v1.FieldProjection
+	(v2.CallType)(0),                        // 18: sf.ethereum.type.v2.CallType
+	(v2.BalanceChange_Reason)(0),            // 19: sf.ethereum.type.v2.BalanceChange.Reason
+	(*v2.BigInt)(nil),                       // 20: sf.ethereum.type.v2.BigInt
+	(*v2.TransactionTraceWithBlockRef)(nil), // 21: sf.ethereum.type.v2.TransactionTraceWithBlockRef
+	(*v2.Log)(nil),                          // 22: sf.ethereum.type.v2.Log
+	(*v2.BlockRef)(nil),                     // 23: sf.ethereum.type.v2.BlockRef
+}
+var file_sf_ethereum_transform_v1_transforms_proto_depIdxs = []int32{
+	3,  // 0: sf.ethereum.transform.v1.CombinedFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	5,  // 1: sf.ethereum.transform.v1.CombinedFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
+	3,  // 2: sf.ethereum.transform.v1.CombinedFilter.exclude_log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	5,  // 3: sf.ethereum.transform.v1.CombinedFilter.exclude_call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
+	6,  // 4: sf.ethereum.transform.v1.CombinedFilter.transaction_filters:type_name -> sf.ethereum.transform.v1.TransactionFilter
+	6,  // 5: sf.ethereum.transform.v1.CombinedFilter.exclude_transaction_filters:type_name -> sf.ethereum.transform.v1.TransactionFilter
+	7,  // 6: sf.ethereum.transform.v1.CombinedFilter.balance_change_filters:type_name -> sf.ethereum.transform.v1.BalanceChangeFilter
+	8,  // 7: sf.ethereum.transform.v1.CombinedFilter.storage_change_filters:type_name -> sf.ethereum.transform.v1.StorageChangeFilter
+	9,  // 8: sf.ethereum.transform.v1.CombinedFilter.contract_creation_filters:type_name -> sf.ethereum.transform.v1.ContractCreationFilter
+	10, // 9: sf.ethereum.transform.v1.CombinedFilter.value_transfer_filters:type_name -> sf.ethereum.transform.v1.ValueTransferFilter
+	1,  // 10: sf.ethereum.transform.v1.CombinedFilter.filter_groups:type_name -> sf.ethereum.transform.v1.FilterGroup
+	5,  // 11: sf.ethereum.transform.v1.FilterGroup.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
+	3,  // 12: sf.ethereum.transform.v1.FilterGroup.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	6,  // 13: sf.ethereum.transform.v1.FilterGroup.transaction_filters:type_name -> sf.ethereum.transform.v1.TransactionFilter
+	3,  // 14: sf.ethereum.transform.v1.MultiLogFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	5,  // 15: sf.ethereum.transform.v1.MultiCallToFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
+	18, // 16: sf.ethereum.transform.v1.CallToFilter.call_types:type_name -> sf.ethereum.type.v2.CallType
+	19, // 17: sf.ethereum.transform.v1.BalanceChangeFilter.reasons:type_name -> sf.ethereum.type.v2.BalanceChange.Reason
+	20, // 18: sf.ethereum.transform.v1.ValueTransferFilter.min_value:type_name -> sf.ethereum.type.v2.BigInt
+	21, // 19: sf.ethereum.transform.v1.TransactionTraces.transaction_traces:type_name -> sf.ethereum.type.v2.TransactionTraceWithBlockRef
+	3,  // 20: sf.ethereum.transform.v1.LogsOnly.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	15, // 21: sf.ethereum.transform.v1.Logs.logs:type_name -> sf.ethereum.transform.v1.LogWithRefs
+	22, // 22: sf.ethereum.transform.v1.LogWithRefs.log:type_name -> sf.ethereum.type.v2.Log
+	23, // 23: sf.ethereum.transform.v1.LogWithRefs.block_ref:type_name -> sf.ethereum.type.v2.BlockRef
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_sf_ethereum_transform_v1_transforms_proto_init() }
+func file_sf_ethereum_transform_v1_transforms_proto_init() {
+	if File_sf_ethereum_transform_v1_transforms_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CombinedFilter); i {
 			case 0:
 				return &v.state
@@ -479,7 +1661,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			}
 		}
 		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiLogFilter); i {
+			switch v := v.(*FilterGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -491,7 +1673,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			}
 		}
 		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogFilter); i {
+			switch v := v.(*MultiLogFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -503,7 +1685,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			}
 		}
 		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiCallToFilter); i {
+			switch v := v.(*LogFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -515,7 +1697,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			}
 		}
 		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CallToFilter); i {
+			switch v := v.(*MultiCallToFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -527,6 +1709,138 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			}
 		}
 		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallToFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalanceChangeFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageChangeFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContractCreationFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValueTransferFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionsOnly); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionTraces); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogsOnly); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Logs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogWithRefs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HeaderOnly); i {
 			case 0:
 				return &v.state
@@ -538,14 +1852,27 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 				return nil
 			}
 		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldProjection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_ethereum_transform_v1_transforms_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,206 @@
+package pbeth
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+// EmptyRootHash is the root hash of an empty trie, e.g. the transactions root of a block without
+// transactions
+var EmptyRootHash = keccak256(rlpBytes(nil))
+
+// CanonicalEncoding returns the encoding of the transaction as hashed in the block's
+// transactions trie: the RLP list of its fields for legacy transactions and the type byte
+// followed by the RLP list of its fields for typed (EIP-2718) transactions. The chain ID is
+// required for typed transactions, whose trace does not record it.
+//
+// Only the transaction types of Ethereum are supported, an error is returned for chain specific
+// ones (e.g. Arbitrum or Optimism types).
+func (trace *TransactionTrace) CanonicalEncoding(chainID *big.Int) ([]byte, error) {
+	to, err := trace.consensusTo()
+	if err != nil {
+		return nil, err
+	}
+
+	if trace.Type == TransactionTrace_TRX_TYPE_LEGACY {
+		return rlpList(
+			rlpUint64(trace.Nonce),
+			rlpBigInt(trace.GasPrice.GetBytes()),
+			rlpUint64(trace.GasLimit),
+			rlpBytes(to),
+			rlpBigInt(trace.Value.GetBytes()),
+			rlpBytes(trace.Input),
+			rlpBigInt(trace.V),
+			rlpBigInt(trace.R),
+			rlpBigInt(trace.S),
+		), nil
+	}
+
+	if chainID == nil {
+		return nil, fmt.Errorf("chain ID is required to encode transaction of type %s", trace.Type)
+	}
+
+	var fields [][]byte
+	switch trace.Type {
+	case TransactionTrace_TRX_TYPE_ACCESS_LIST:
+		fields = [][]byte{
+			rlpBytes(chainID.Bytes()),
+			rlpUint64(trace.Nonce),
+			rlpBigInt(trace.GasPrice.GetBytes()),
+			rlpUint64(trace.GasLimit),
+			rlpBytes(to),
+			rlpBigInt(trace.Value.GetBytes()),
+			rlpBytes(trace.Input),
+			trace.rlpAccessList(),
+		}
+
+	case TransactionTrace_TRX_TYPE_DYNAMIC_FEE:
+		fields = [][]byte{
+			rlpBytes(chainID.Bytes()),
+			rlpUint64(trace.Nonce),
+			rlpBigInt(trace.MaxPriorityFeePerGas.GetBytes()),
+			rlpBigInt(trace.MaxFeePerGas.GetBytes()),
+			rlpUint64(trace.GasLimit),
+			rlpBytes(to),
+			rlpBigInt(trace.Value.GetBytes()),
+			rlpBytes(trace.Input),
+			trace.rlpAccessList(),
+		}
+
+	case TransactionTrace_TRX_TYPE_BLOB:
+		blobHashes := make([][]byte, len(trace.BlobHashes))
+		for i, blobHash := range trace.BlobHashes {
+			blobHashes[i] = rlpBytes(blobHash)
+		}
+
+		fields = [][]byte{
+			rlpBytes(chainID.Bytes()),
+			rlpUint64(trace.Nonce),
+			rlpBigInt(trace.MaxPriorityFeePerGas.GetBytes()),
+			rlpBigInt(trace.MaxFeePerGas.GetBytes()),
+			rlpUint64(trace.GasLimit),
+			rlpBytes(trace.To),
+			rlpBigInt(trace.Value.GetBytes()),
+			rlpBytes(trace.Input),
+			trace.rlpAccessList(),
+			rlpBigInt(trace.BlobGasFeeCap.GetBytes()),
+			rlpList(blobHashes...),
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %s", trace.Type)
+	}
+
+	fields = append(fields, rlpBigInt(trace.V), rlpBigInt(trace.R), rlpBigInt(trace.S))
+	return append([]byte{byte(trace.Type)}, rlpList(fields...)...), nil
+}
+
+// consensusTo is the recipient of the transaction as signed, the trace's `to` being the address
+// of the created contract for contract creations. Contract creations are told apart by their root
+// call, the receipt not recording the created contract. Without calls (e.g. `BASE` blocks), a
+// transaction whose `to` is the address created by its sender at its nonce is a contract creation
+// whose encoding cannot be known for sure, an error is returned for it.
+func (trace *TransactionTrace) consensusTo() ([]byte, error) {
+	if len(trace.Calls) > 0 {
+		if trace.Calls[0].CallType == CallType_CREATE {
+			return nil, nil
+		}
+		return trace.To, nil
+	}
+
+	if len(trace.To) != 0 && bytes.Equal(trace.To, createdContractAddress(trace.From, trace.Nonce)) {
+		return nil, fmt.Errorf("transaction is likely a contract creation, which cannot be told apart from a call without the transaction's calls")
+	}
+	return trace.To, nil
+}
+
+// createdContractAddress is the address of the contract created by the sender at the given nonce
+func createdContractAddress(sender []byte, nonce uint64) []byte {
+	return keccak256(rlpList(rlpBytes(sender), rlpUint64(nonce)))[12:]
+}
+
+func (trace *TransactionTrace) rlpAccessList() []byte {
+	tuples := make([][]byte, len(trace.AccessList))
+	for i, tuple := range trace.AccessList {
+		storageKeys := make([][]byte, len(tuple.StorageKeys))
+		for j, storageKey := range tuple.StorageKeys {
+			storageKeys[j] = rlpBytes(storageKey)
+		}
+		tuples[i] = rlpList(rlpBytes(tuple.Address), rlpList(storageKeys...))
+	}
+	return rlpList(tuples...)
+}
+
+// ReceiptCanonicalEncoding returns the encoding of the transaction's receipt as hashed in the
+// block's receipts trie, prefixed by the transaction type byte for typed transactions
+func (trace *TransactionTrace) ReceiptCanonicalEncoding() ([]byte, error) {
+	if trace.Receipt == nil {
+		return nil, fmt.Errorf("transaction %x has no receipt", trace.Hash)
+	}
+
+	switch trace.Type {
+	case TransactionTrace_TRX_TYPE_LEGACY, TransactionTrace_TRX_TYPE_ACCESS_LIST, TransactionTrace_TRX_TYPE_DYNAMIC_FEE, TransactionTrace_TRX_TYPE_BLOB:
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %s", trace.Type)
+	}
+
+	// Receipts before Byzantium record the intermediate state root instead of the status
+	statusOrStateRoot := rlpBytes(trace.Receipt.StateRoot)
+	if len(trace.Receipt.StateRoot) == 0 {
+		statusOrStateRoot = rlpUint64(0)
+		if trace.Status == TransactionTraceStatus_SUCCEEDED {
+			statusOrStateRoot = rlpUint64(1)
+		}
+	}
+
+	logs := make([][]byte, len(trace.Receipt.Logs))
+	for i, log := range trace.Receipt.Logs {
+		topics := make([][]byte, len(log.Topics))
+		for j, topic := range log.Topics {
+			topics[j] = rlpBytes(topic)
+		}
+		logs[i] = rlpList(rlpBytes(log.Address), rlpList(topics...), rlpBytes(log.Data))
+	}
+
+	encoded := rlpList(
+		statusOrStateRoot,
+		rlpUint64(trace.Receipt.CumulativeGasUsed),
+		rlpBytes(trace.Receipt.LogsBloom),
+		rlpList(logs...),
+	)
+
+	if trace.Type == TransactionTrace_TRX_TYPE_LEGACY {
+		return encoded, nil
+	}
+	return append([]byte{byte(trace.Type)}, encoded...), nil
+}
+
+// TransactionsRoot computes the root of the transactions trie of the given transactions, see
+// CanonicalEncoding for the chain ID
+func TransactionsRoot(traces []*TransactionTrace, chainID *big.Int) ([]byte, error) {
+	items := make([][]byte, len(traces))
+	for i, trace := range traces {
+		encoded, err := trace.CanonicalEncoding(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("encoding transaction %x: %w", trace.Hash, err)
+		}
+		items[i] = encoded
+	}
+
+	return indexedTrieRoot(items), nil
+}
+
+// ReceiptsRoot computes the root of the receipts trie of the given transactions
+func ReceiptsRoot(traces []*TransactionTrace) ([]byte, error) {
+	items := make([][]byte, len(traces))
+	for i, trace := range traces {
+		encoded, err := trace.ReceiptCanonicalEncoding()
+		if err != nil {
+			return nil, fmt.Errorf("encoding receipt of transaction %x: %w", trace.Hash, err)
+		}
+		items[i] = encoded
+	}
+
+	return indexedTrieRoot(items), nil
+}
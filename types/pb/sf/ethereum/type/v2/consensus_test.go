@@ -0,0 +1,48 @@
+package pbeth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatedContractAddress(t *testing.T) {
+	sender, _ := hex.DecodeString("6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	assert.Equal(t, "cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d", hex.EncodeToString(createdContractAddress(sender, 0)))
+	assert.Equal(t, "343c43a37d37dff08ae8c4a11544c718abb4fcf8", hex.EncodeToString(createdContractAddress(sender, 1)))
+}
+
+func TestTransactionTrace_ConsensusTo(t *testing.T) {
+	sender, _ := hex.DecodeString("6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	recipient, _ := hex.DecodeString("3333333333333333333333333333333333333333")
+	created := createdContractAddress(sender, 1)
+
+	tests := []struct {
+		name        string
+		trace       *TransactionTrace
+		expected    []byte
+		expectedErr bool
+	}{
+		{"call", &TransactionTrace{From: sender, Nonce: 1, To: recipient, Calls: []*Call{{CallType: CallType_CALL}}}, recipient, false},
+		{"creation", &TransactionTrace{From: sender, Nonce: 1, To: created, Calls: []*Call{{CallType: CallType_CREATE}}}, nil, false},
+		{"base block call", &TransactionTrace{From: sender, Nonce: 1, To: recipient}, recipient, false},
+		{"base block creation", &TransactionTrace{From: sender, Nonce: 1, To: created}, nil, true},
+		{"base block creation without to", &TransactionTrace{From: sender, Nonce: 1}, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			to, err := test.trace.consensusTo()
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, to)
+		})
+	}
+}
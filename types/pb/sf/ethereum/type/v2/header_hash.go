@@ -0,0 +1,151 @@
+package pbeth
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// HeaderFork selects the fields part of a block header's RLP encoding, each fork appending
+// fields to the ones of the previous fork
+type HeaderFork int
+
+const (
+	// HeaderForkLegacy is the header before London, made of the 15 original fields
+	HeaderForkLegacy HeaderFork = iota
+	// HeaderForkLondon appends the base fee per gas (EIP-1559)
+	HeaderForkLondon
+	// HeaderForkShanghai appends the withdrawals root (EIP-4895)
+	HeaderForkShanghai
+	// HeaderForkCancun appends the blob gas used, the excess blob gas (EIP-4844) and the parent
+	// beacon block root (EIP-4788)
+	HeaderForkCancun
+	// HeaderForkPrague appends the execution requests hash (EIP-7685)
+	HeaderForkPrague
+)
+
+var headerForkNames = map[HeaderFork]string{
+	HeaderForkLegacy:   "legacy",
+	HeaderForkLondon:   "london",
+	HeaderForkShanghai: "shanghai",
+	HeaderForkCancun:   "cancun",
+	HeaderForkPrague:   "prague",
+}
+
+func (f HeaderFork) String() string {
+	if name, found := headerForkNames[f]; found {
+		return name
+	}
+	return fmt.Sprintf("HeaderFork(%d)", int(f))
+}
+
+// ParseHeaderFork returns the fork whose name is given, as returned by HeaderFork.String
+func ParseHeaderFork(in string) (HeaderFork, error) {
+	for fork, name := range headerForkNames {
+		if name == in {
+			return fork, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown header fork %q, valid values are 'legacy', 'london', 'shanghai', 'cancun' and 'prague'", in)
+}
+
+// EmptyRequestsHash is the requests hash of a Prague block without execution requests
+var EmptyRequestsHash = func() []byte {
+	hash := sha256.Sum256(nil)
+	return hash[:]
+}()
+
+// InferredFork returns the latest fork whose fields are set on the header. Prague is never
+// inferred, the execution requests hash it introduces not being recorded by BlockHeader.
+func (h *BlockHeader) InferredFork() HeaderFork {
+	switch {
+	case h.BlobGasUsed != nil || h.ExcessBlobGas != nil || len(h.ParentBeaconRoot) != 0:
+		return HeaderForkCancun
+	case len(h.WithdrawalsRoot) != 0:
+		return HeaderForkShanghai
+	case h.BaseFeePerGas != nil:
+		return HeaderForkLondon
+	default:
+		return HeaderForkLegacy
+	}
+}
+
+// CanonicalEncoding returns the RLP encoding of the header's fields according to the fork's
+// rules. The requests hash is only used, and is required, for Prague, BlockHeader not
+// recording it.
+func (h *BlockHeader) CanonicalEncoding(fork HeaderFork, requestsHash []byte) ([]byte, error) {
+	if fork < HeaderForkLegacy || fork > HeaderForkPrague {
+		return nil, fmt.Errorf("unknown header fork %s", fork)
+	}
+
+	fields := [][]byte{
+		rlpBytes(fixedBytes(h.ParentHash, 32)),
+		rlpBytes(fixedBytes(h.UncleHash, 32)),
+		rlpBytes(fixedBytes(h.Coinbase, 20)),
+		rlpBytes(fixedBytes(h.StateRoot, 32)),
+		rlpBytes(fixedBytes(h.TransactionsRoot, 32)),
+		rlpBytes(fixedBytes(h.ReceiptRoot, 32)),
+		rlpBytes(fixedBytes(h.LogsBloom, 256)),
+		rlpBigInt(h.Difficulty.GetBytes()),
+		rlpUint64(h.Number),
+		rlpUint64(h.GasLimit),
+		rlpUint64(h.GasUsed),
+		rlpUint64(uint64(h.Timestamp.GetSeconds())),
+		rlpBytes(h.ExtraData),
+		rlpBytes(fixedBytes(h.MixHash, 32)),
+		// The nonce is encoded as an 8 bytes string and not as an integer
+		rlpBytes(fixedUint64(h.Nonce)),
+	}
+
+	if fork >= HeaderForkLondon {
+		if h.BaseFeePerGas == nil {
+			return nil, fmt.Errorf("base fee per gas is required for %s header", fork)
+		}
+		fields = append(fields, rlpBigInt(h.BaseFeePerGas.Bytes))
+	}
+
+	if fork >= HeaderForkShanghai {
+		fields = append(fields, rlpBytes(fixedBytes(h.WithdrawalsRoot, 32)))
+	}
+
+	if fork >= HeaderForkCancun {
+		if h.BlobGasUsed == nil || h.ExcessBlobGas == nil {
+			return nil, fmt.Errorf("blob gas used and excess blob gas are required for %s header", fork)
+		}
+		fields = append(fields, rlpUint64(*h.BlobGasUsed), rlpUint64(*h.ExcessBlobGas), rlpBytes(fixedBytes(h.ParentBeaconRoot, 32)))
+	}
+
+	if fork >= HeaderForkPrague {
+		if len(requestsHash) != 32 {
+			return nil, fmt.Errorf("requests hash of 32 bytes is required for %s header, got %d bytes", fork, len(requestsHash))
+		}
+		fields = append(fields, rlpBytes(requestsHash))
+	}
+
+	return rlpList(fields...), nil
+}
+
+// ComputeHash returns the keccak hash of the header's canonical encoding for the fork, which
+// is the block hash, see CanonicalEncoding for the requests hash
+func (h *BlockHeader) ComputeHash(fork HeaderFork, requestsHash []byte) ([]byte, error) {
+	encoded, err := h.CanonicalEncoding(fork, requestsHash)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256(encoded), nil
+}
+
+// fixedBytes returns the zero value of a fixed size field left empty, Firehose not always
+// recording zero hashes and addresses
+func fixedBytes(in []byte, size int) []byte {
+	if len(in) == 0 {
+		return make([]byte, size)
+	}
+	return in
+}
+
+func fixedUint64(in uint64) []byte {
+	out := make([]byte, 8)
+	binary.BigEndian.PutUint64(out, in)
+	return out
+}
@@ -0,0 +1,106 @@
+package pbeth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func mustDecodeHex(t *testing.T, in string) []byte {
+	t.Helper()
+
+	out, err := hex.DecodeString(in)
+	require.NoError(t, err)
+	return out
+}
+
+// mainnetGenesisHeader is the header of Ethereum Mainnet's block #0
+func mainnetGenesisHeader(t *testing.T) *BlockHeader {
+	return &BlockHeader{
+		UncleHash:        mustDecodeHex(t, "1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"),
+		StateRoot:        mustDecodeHex(t, "d7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544"),
+		TransactionsRoot: EmptyRootHash,
+		ReceiptRoot:      EmptyRootHash,
+		Difficulty:       &BigInt{Bytes: mustDecodeHex(t, "0400000000")},
+		GasLimit:         5000,
+		Timestamp:        &timestamppb.Timestamp{},
+		ExtraData:        mustDecodeHex(t, "11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa"),
+		Nonce:            0x42,
+	}
+}
+
+func TestBlockHeader_ComputeHash(t *testing.T) {
+	header := mainnetGenesisHeader(t)
+
+	assert.Equal(t, HeaderForkLegacy, header.InferredFork())
+
+	hash, err := header.ComputeHash(HeaderForkLegacy, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3", hex.EncodeToString(hash))
+
+	_, err = header.ComputeHash(HeaderForkLondon, nil)
+	assert.EqualError(t, err, "base fee per gas is required for london header")
+}
+
+func TestBlockHeader_CanonicalEncoding_Forks(t *testing.T) {
+	blobGasUsed, excessBlobGas := uint64(131072), uint64(0)
+
+	header := mainnetGenesisHeader(t)
+	header.BaseFeePerGas = &BigInt{Bytes: []byte{0x07}}
+	header.WithdrawalsRoot = EmptyRootHash
+	header.BlobGasUsed = &blobGasUsed
+	header.ExcessBlobGas = &excessBlobGas
+	header.ParentBeaconRoot = EmptyRootHash
+
+	assert.Equal(t, HeaderForkCancun, header.InferredFork())
+
+	legacy, err := header.CanonicalEncoding(HeaderForkLegacy, nil)
+	require.NoError(t, err)
+
+	// Each fork appends its fields to the ones of the previous fork
+	appended := map[HeaderFork][]byte{
+		HeaderForkLondon:   rlpBytes([]byte{0x07}),
+		HeaderForkShanghai: rlpBytes(EmptyRootHash),
+		HeaderForkCancun:   append(append(rlpUint64(blobGasUsed), rlpUint64(excessBlobGas)...), rlpBytes(EmptyRootHash)...),
+		HeaderForkPrague:   rlpBytes(EmptyRequestsHash),
+	}
+
+	previous := legacy
+	for _, fork := range []HeaderFork{HeaderForkLondon, HeaderForkShanghai, HeaderForkCancun, HeaderForkPrague} {
+		encoded, err := header.CanonicalEncoding(fork, EmptyRequestsHash)
+		require.NoError(t, err, fork)
+
+		assert.Equal(t, hex.EncodeToString(rlpList(rlpListContent(t, previous), appended[fork])), hex.EncodeToString(encoded), fork)
+		previous = encoded
+	}
+
+	_, err = header.CanonicalEncoding(HeaderForkPrague, nil)
+	assert.EqualError(t, err, "requests hash of 32 bytes is required for prague header, got 0 bytes")
+
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", hex.EncodeToString(EmptyRequestsHash))
+}
+
+func TestParseHeaderFork(t *testing.T) {
+	for _, fork := range []HeaderFork{HeaderForkLegacy, HeaderForkLondon, HeaderForkShanghai, HeaderForkCancun, HeaderForkPrague} {
+		parsed, err := ParseHeaderFork(fork.String())
+		require.NoError(t, err)
+		assert.Equal(t, fork, parsed)
+	}
+
+	_, err := ParseHeaderFork("osaka")
+	assert.Error(t, err)
+}
+
+// rlpListContent returns the encoded items of an encoded RLP list
+func rlpListContent(t *testing.T, encoded []byte) []byte {
+	t.Helper()
+
+	require.True(t, len(encoded) > 0 && encoded[0] >= 0xc0)
+	if encoded[0] <= 0xf7 {
+		return encoded[1:]
+	}
+	return encoded[1+int(encoded[0]-0xf7):]
+}
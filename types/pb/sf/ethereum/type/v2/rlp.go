@@ -0,0 +1,59 @@
+package pbeth
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// The RLP encoding used to compute the consensus commitments of a block (transactions and
+// receipts tries), only covers encoding, each item being either a string of bytes or a list
+// whose items are already encoded.
+
+func rlpBytes(in []byte) []byte {
+	if len(in) == 1 && in[0] < 0x80 {
+		return []byte{in[0]}
+	}
+
+	return append(rlpLength(len(in), 0x80), in...)
+}
+
+func rlpUint64(in uint64) []byte {
+	if in == 0 {
+		return []byte{0x80}
+	}
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], in)
+	return rlpBytes(buf[8-(bits.Len64(in)+7)/8:])
+}
+
+// rlpBigInt encodes the big-endian bytes of a positive integer, without its leading zeros
+func rlpBigInt(in []byte) []byte {
+	return rlpBytes(new(big.Int).SetBytes(in).Bytes())
+}
+
+func rlpList(items ...[]byte) []byte {
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+
+	out := rlpLength(size, 0xc0)
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func rlpLength(length int, offset byte) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(length))
+	lengthBytes := buf[8-(bits.Len64(uint64(length))+7)/8:]
+
+	return append([]byte{offset + 55 + byte(len(lengthBytes))}, lengthBytes...)
+}
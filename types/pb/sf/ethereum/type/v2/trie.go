@@ -0,0 +1,119 @@
+package pbeth
+
+import (
+	"bytes"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func keccak256(data []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(data)
+	return hasher.Sum(nil)
+}
+
+type triePair struct {
+	key   []byte
+	value []byte
+}
+
+// indexedTrieRoot returns the root hash of the Merkle Patricia trie holding each item under the
+// RLP encoding of its index, which is how the transactions, receipts and withdrawals tries of a
+// block are built
+func indexedTrieRoot(items [][]byte) []byte {
+	pairs := make([]triePair, len(items))
+	for i, item := range items {
+		pairs[i] = triePair{key: toNibbles(rlpUint64(uint64(i))), value: item}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return bytes.Compare(pairs[i].key, pairs[j].key) < 0 })
+
+	// The root is always hashed, even when its encoding is shorter than a hash
+	return keccak256(trieNode(pairs, 0))
+}
+
+// trieNode returns the encoding of the node holding the pairs, sorted by key, whose keys all
+// share the same first `depth` nibbles
+func trieNode(pairs []triePair, depth int) []byte {
+	switch len(pairs) {
+	case 0:
+		return rlpBytes(nil)
+	case 1:
+		return rlpList(rlpBytes(hexPrefix(pairs[0].key[depth:], true)), rlpBytes(pairs[0].value))
+	}
+
+	// Pairs being sorted, the prefix common to all keys is the one of the first and last ones
+	first, last := pairs[0].key[depth:], pairs[len(pairs)-1].key[depth:]
+	prefixLength := 0
+	for prefixLength < len(first) && prefixLength < len(last) && first[prefixLength] == last[prefixLength] {
+		prefixLength++
+	}
+
+	if prefixLength > 0 {
+		return rlpList(rlpBytes(hexPrefix(first[:prefixLength], false)), trieNodeReference(trieNode(pairs, depth+prefixLength)))
+	}
+
+	items := make([][]byte, 17)
+	for i := range items {
+		items[i] = rlpBytes(nil)
+	}
+
+	for start := 0; start < len(pairs); {
+		if len(pairs[start].key) == depth {
+			items[16] = rlpBytes(pairs[start].value)
+			start++
+			continue
+		}
+
+		nibble := pairs[start].key[depth]
+		end := start + 1
+		for end < len(pairs) && pairs[end].key[depth] == nibble {
+			end++
+		}
+
+		items[nibble] = trieNodeReference(trieNode(pairs[start:end], depth+1))
+		start = end
+	}
+
+	return rlpList(items...)
+}
+
+// trieNodeReference returns how a node is referenced by its parent, embedded when its encoding
+// is shorter than a hash and by its hash otherwise
+func trieNodeReference(encoded []byte) []byte {
+	if len(encoded) < 32 {
+		return encoded
+	}
+	return rlpBytes(keccak256(encoded))
+}
+
+func toNibbles(key []byte) []byte {
+	out := make([]byte, 2*len(key))
+	for i, b := range key {
+		out[2*i] = b >> 4
+		out[2*i+1] = b & 0x0f
+	}
+	return out
+}
+
+// hexPrefix is the compact encoding of the nibbles of a leaf or extension node's path
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	var flag byte
+	if leaf {
+		flag = 2
+	}
+
+	out := make([]byte, 0, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		out = append(out, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		out = append(out, flag<<4)
+	}
+
+	for i := 0; i < len(nibbles); i += 2 {
+		out = append(out, nibbles[i]<<4|nibbles[i+1])
+	}
+	return out
+}
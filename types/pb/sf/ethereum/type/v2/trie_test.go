@@ -0,0 +1,49 @@
+package pbeth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRLP(t *testing.T) {
+	assert.Equal(t, "83646f67", hex.EncodeToString(rlpBytes([]byte("dog"))))
+	assert.Equal(t, "c88363617483646f67", hex.EncodeToString(rlpList(rlpBytes([]byte("cat")), rlpBytes([]byte("dog")))))
+	assert.Equal(t, "80", hex.EncodeToString(rlpUint64(0)))
+	assert.Equal(t, "0f", hex.EncodeToString(rlpUint64(15)))
+	assert.Equal(t, "820400", hex.EncodeToString(rlpUint64(1024)))
+	assert.Equal(t, "820400", hex.EncodeToString(rlpBigInt([]byte{0x00, 0x04, 0x00})))
+	assert.Equal(t, "c0", hex.EncodeToString(rlpList()))
+
+	long := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+	assert.Equal(t, "b838"+hex.EncodeToString(long), hex.EncodeToString(rlpBytes(long)))
+}
+
+func testTrieRoot(entries map[string]string) string {
+	var pairs []triePair
+	for key, value := range entries {
+		pairs = append(pairs, triePair{key: toNibbles([]byte(key)), value: []byte(value)})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return bytes.Compare(pairs[i].key, pairs[j].key) < 0 })
+
+	return hex.EncodeToString(keccak256(trieNode(pairs, 0)))
+}
+
+func TestTrieRoot(t *testing.T) {
+	assert.Equal(t, "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", hex.EncodeToString(EmptyRootHash))
+	assert.Equal(t, hex.EncodeToString(EmptyRootHash), hex.EncodeToString(indexedTrieRoot(nil)))
+
+	assert.Equal(t, "8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3", testTrieRoot(map[string]string{
+		"doe":          "reindeer",
+		"dog":          "puppy",
+		"dogglesworth": "cat",
+	}))
+
+	assert.Equal(t, "d23786fb4a010da3ce639d66d5e904a11dbc02746d1ce25029e53290cabf28ab", testTrieRoot(map[string]string{
+		"A": strings.Repeat("a", 50),
+	}))
+}
@@ -186,6 +186,15 @@ func (call *Call) Method() []byte {
 	return nil
 }
 
+// Method returns the 4-bytes method signature of the transaction's input, nil if
+// the input is too short to contain one.
+func (trace *TransactionTrace) Method() []byte {
+	if len(trace.Input) >= 4 {
+		return trace.Input[0:4]
+	}
+	return nil
+}
+
 func MustBalanceChangeReasonFromString(reason string) BalanceChange_Reason {
 	if reason == "ignored" {
 		panic("receive ignored balance change reason, we do not expect this as valid input for block generation")